@@ -6,6 +6,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 
 	"CorrectQuiz.com/quiz/internal/collection"
@@ -33,8 +34,9 @@ type App struct {
 	database    *gorm.DB
 	firebaseApp *firebase.App
 
-	quizService *service.QuizService
-	netService  *service.NetService
+	quizService    *service.QuizService
+	sessionService *service.GameSessionService
+	netService     *service.NetService
 }
 
 func (a *App) setUpFirebase() {
@@ -89,6 +91,7 @@ func (a *App) setUpHttp() {
 	quizRepo := collection.NewQuizRepository(a.database)
 	userRepo := collection.NewGormUserRepository(a.database)
 	tokenRepo := collection.NewGormTokenRepository(a.database)
+	sessionRepo := collection.NewGameSessionRepository(a.database)
 	emailService := service.NewBrevoEmailService()
 
 	firebaseAuthClient, err := a.firebaseApp.Auth(context.Background())
@@ -98,11 +101,18 @@ func (a *App) setUpHttp() {
 
 	authService := service.NewAuthService(userRepo, firebaseAuthClient, tokenRepo, emailService)
 	a.quizService = service.Quiz(quizRepo)
-	a.netService = service.Net(a.quizService)
+	a.sessionService = service.NewGameSessionService(sessionRepo)
+	a.netService, err = service.Net(a.quizService, a.sessionService, gameCodeOptions())
+	if err != nil {
+		log.Fatalf("error creating game registry: %v\n", err)
+	}
+	a.netService.StartReaper(context.Background(), lifecycleOptions())
+	exportService := service.NewExportService(a.sessionService)
+	analysisService := service.NewAnalysisService(a.quizService, a.sessionService)
 
 	authController := controller.NewAuthController(authService, store, firebaseAuthClient, userRepo, tokenRepo, emailService)
-	gameController := controller.NewGameController(a.netService)
-	quizController := controller.Quiz(a.quizService)
+	gameController := controller.NewGameController(a.netService, a.sessionService, exportService)
+	quizController := controller.Quiz(a.quizService, analysisService)
 	wsController := controller.Ws(a.netService)
 	app.Static("/uploads", "./public/uploads")
 
@@ -123,9 +133,8 @@ func (a *App) setUpHttp() {
 	app.Post("/api/auth/resend-verification", authController.ResendVerificationEmail)
 	app.Post("/api/auth/guest-login", authController.GuestLogin)
 	app.Post("/set-initial-claims", authController.HandleSetInitialClaims)
-	app.Get("/api/games/:gameCode/export/csv", gameController.ExportGameResultsCSV)
 	app.Get("/api/quizzes/:quizId", quizController.GetQuizById)
-	app.Get("/ws", websocket.New(wsController.Ws))
+	app.Get("/ws", middleware.WebSocketAuth(), websocket.New(wsController.Ws))
 
 	app.Get("/api/users/email/:username", authController.GetUserEmailByUsername)
 	app.Post("/api/game/check", wsController.CheckGamePin)
@@ -135,12 +144,54 @@ func (a *App) setUpHttp() {
 	api.Get("/quizzes", quizController.GetCorrect)
 	api.Post("/quizzes", quizController.CreateQuiz)
 	api.Put("/quizzes/:quizId", quizController.UpdateQuizById)
+	api.Get("/quizzes/:quizId/analysis", quizController.GetQuizAnalysis)
 	api.Delete("/quizzes/:id", quizController.DeleteQuizById)
 	api.Delete("/questions/:id", quizController.DeleteQuestionById)
 
+	api.Get("/sessions", gameController.ListSessions)
+	api.Get("/sessions/:sessionId", gameController.GetSession)
+	api.Get("/sessions/:sessionId/export/:format", gameController.ExportSession)
+	api.Get("/games/stats", gameController.GetGameStats)
+	api.Get("/games/:gameCode/export/:format", gameController.ExportGameResults)
+
+	api.Get("/assignments", gameController.ListAssignments)
+	api.Post("/assignments", gameController.PublishAssignment)
+	api.Delete("/assignments/:gameCode", gameController.CloseAssignment)
+
 	a.httpServer = app
 }
 
+func gameCodeOptions() service.CodeOptions {
+	options := service.DefaultCodeOptions()
+	if alphabet := os.Getenv("GAME_CODE_ALPHABET"); alphabet != "" {
+		options.Alphabet = alphabet
+	}
+	if length, err := strconv.Atoi(os.Getenv("GAME_CODE_LENGTH")); err == nil {
+		options.Length = length
+	}
+	if cooldown, err := time.ParseDuration(os.Getenv("GAME_CODE_COOLDOWN")); err == nil {
+		options.Cooldown = cooldown
+	}
+	return options
+}
+
+func lifecycleOptions() service.LifecycleOptions {
+	options := service.DefaultLifecycleOptions()
+	if ttl, err := time.ParseDuration(os.Getenv("GAME_LOBBY_IDLE_TTL")); err == nil && ttl > 0 {
+		options.LobbyIdleTTL = ttl
+	}
+	if ttl, err := time.ParseDuration(os.Getenv("GAME_ACTIVE_IDLE_TTL")); err == nil && ttl > 0 {
+		options.ActiveIdleTTL = ttl
+	}
+	if ttl, err := time.ParseDuration(os.Getenv("GAME_FINISHED_TTL")); err == nil && ttl > 0 {
+		options.FinishedTTL = ttl
+	}
+	if interval, err := time.ParseDuration(os.Getenv("GAME_SWEEP_INTERVAL")); err == nil && interval > 0 {
+		options.SweepInterval = interval
+	}
+	return options
+}
+
 func (a *App) setUpDb() {
 	dsn := os.Getenv("DATABASE_URL")
 	if dsn == "" {
@@ -163,6 +214,9 @@ func (a *App) setUpDb() {
 		&entity.QuizQuestion{},
 		&entity.QuizChoice{},
 		&entity.EmailVerificationToken{},
+		&entity.GameSession{},
+		&entity.GamePlayerResult{},
+		&entity.GameAnswer{},
 	)
 
 	a.database = db
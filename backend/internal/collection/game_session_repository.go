@@ -0,0 +1,113 @@
+package collection
+
+import (
+	"time"
+
+	"CorrectQuiz.com/quiz/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type GameSessionRepository interface {
+	CreateSession(session *entity.GameSession) error
+	EndSession(id uint, endedAt time.Time) error
+	SavePlayerResults(results []*entity.GamePlayerResult) error
+	CreateAnswers(answers []entity.GameAnswer) error
+	GetSessionsByHost(hostUserID uint) ([]entity.GameSession, error)
+	GetSessionById(id uint) (*entity.GameSession, error)
+	GetSessionsByQuiz(quizID uint) ([]entity.GameSession, error)
+	GetLatestSessionByCode(code string, hostUserID uint) (*entity.GameSession, error)
+}
+
+type gameSessionGormRepository struct {
+	db *gorm.DB
+}
+
+func NewGameSessionRepository(database *gorm.DB) GameSessionRepository {
+	return &gameSessionGormRepository{
+		db: database,
+	}
+}
+
+func (r *gameSessionGormRepository) CreateSession(session *entity.GameSession) error {
+	return r.db.Omit(clause.Associations).Create(session).Error
+}
+
+func (r *gameSessionGormRepository) EndSession(id uint, endedAt time.Time) error {
+	return r.db.Model(&entity.GameSession{}).Where("id = ?", id).Update("ended_at", endedAt).Error
+}
+
+func (r *gameSessionGormRepository) SavePlayerResults(results []*entity.GamePlayerResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, result := range results {
+			if err := tx.Omit(clause.Associations).Save(result).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *gameSessionGormRepository) CreateAnswers(answers []entity.GameAnswer) error {
+	if len(answers) == 0 {
+		return nil
+	}
+	return r.db.CreateInBatches(answers, 100).Error
+}
+
+func (r *gameSessionGormRepository) GetSessionsByHost(hostUserID uint) ([]entity.GameSession, error) {
+	var sessions []entity.GameSession
+	result := r.db.
+		Where("host_user_id = ?", hostUserID).
+		Order("started_at DESC").
+		Find(&sessions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return sessions, nil
+}
+
+func (r *gameSessionGormRepository) GetSessionById(id uint) (*entity.GameSession, error) {
+	var session entity.GameSession
+	result := r.db.
+		Preload("Players", func(db *gorm.DB) *gorm.DB {
+			return db.Order("points DESC")
+		}).
+		Preload("Players.Answers", func(db *gorm.DB) *gorm.DB {
+			return db.Order("question_index ASC")
+		}).
+		First(&session, "id = ?", id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &session, nil
+}
+
+func (r *gameSessionGormRepository) GetSessionsByQuiz(quizID uint) ([]entity.GameSession, error) {
+	var sessions []entity.GameSession
+	result := r.db.
+		Preload("Players").
+		Preload("Players.Answers").
+		Where("quiz_id = ?", quizID).
+		Order("started_at DESC").
+		Find(&sessions)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return sessions, nil
+}
+
+func (r *gameSessionGormRepository) GetLatestSessionByCode(code string, hostUserID uint) (*entity.GameSession, error) {
+	var session entity.GameSession
+	result := r.db.
+		Where("code = ? AND host_user_id = ?", code, hostUserID).
+		Order("started_at DESC").
+		First(&session)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return r.GetSessionById(session.ID)
+}
@@ -1,8 +1,7 @@
 package controller
 
 import (
-	"bytes"
-	"encoding/csv"
+	"errors"
 	"fmt"
 	"strconv"
 
@@ -11,53 +10,136 @@ import (
 )
 
 type GameController struct {
-	netService *service.NetService
+	netService     *service.NetService
+	sessionService *service.GameSessionService
+	exportService  *service.ExportService
 }
 
-func NewGameController(ns *service.NetService) *GameController {
-	return &GameController{netService: ns}
+func NewGameController(ns *service.NetService, ss *service.GameSessionService, es *service.ExportService) *GameController {
+	return &GameController{netService: ns, sessionService: ss, exportService: es}
 }
 
-func (gc *GameController) ExportGameResultsCSV(c *fiber.Ctx) error {
-	gameCode := c.Params("gameCode")
+func (gc *GameController) GetGameStats(c *fiber.Ctx) error {
+	return c.JSON(gc.netService.Stats())
+}
+
+type PublishAssignmentRequest struct {
+	QuizID uint `json:"quizId"`
+	service.AssignmentOptions
+}
+
+func (gc *GameController) PublishAssignment(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok || userID == 0 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in session"})
+	}
+
+	var req PublishAssignmentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse body"})
+	}
+
+	assignment, err := gc.netService.PublishAssignment(req.QuizID, userID, req.AssignmentOptions)
+	if errors.Is(err, service.ErrAssignmentWindow) || errors.Is(err, service.ErrQuizHasNoQuestions) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Quiz not found"})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(assignment)
+}
+
+func (gc *GameController) ListAssignments(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok || userID == 0 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in session"})
+	}
+
+	return c.JSON(gc.netService.AssignmentsByOwner(userID))
+}
+
+func (gc *GameController) CloseAssignment(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok || userID == 0 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in session"})
+	}
+
+	if err := gc.netService.CloseAssignment(c.Params("gameCode"), userID); err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+func (gc *GameController) ListSessions(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok || userID == 0 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in session"})
+	}
 
-	game, err := gc.netService.FindActiveGameByCode(gameCode)
-	if err != nil || game == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Active game with this code not found"})
+	sessions, err := gc.sessionService.GetSessionsByHost(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	var buf bytes.Buffer
-	writer := csv.NewWriter(&buf)
+	return c.JSON(sessions)
+}
 
-	header := []string{"Player Name", "Final Score", "CorrectStreak"}
-	if err := writer.Write(header); err != nil {
-		return c.SendStatus(fiber.StatusInternalServerError)
+func (gc *GameController) GetSession(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok || userID == 0 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in session"})
 	}
 
-	players := game.Players
+	sessionId, err := strconv.ParseUint(c.Params("sessionId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
 
-	for _, player := range players {
-		correctCount := 0
-		if count, ok := game.GetCorrectAnswerCount(player.Id); ok {
-			correctCount = count
-		}
-		row := []string{
-			player.Name,
-			strconv.Itoa(player.Points),
-			strconv.Itoa(correctCount),
-		}
-		if err := writer.Write(row); err != nil {
-		}
+	session, err := gc.sessionService.GetSessionForHost(uint(sessionId), userID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Game session not found"})
 	}
 
-	writer.Flush()
+	return c.JSON(session)
+}
+
+func (gc *GameController) ExportGameResults(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok || userID == 0 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in session"})
+	}
 
-	if err := writer.Error(); err != nil {
-		return c.SendStatus(fiber.StatusInternalServerError)
+	file, err := gc.exportService.ExportLatestSession(c.Params("gameCode"), userID, service.ExportFormat(c.Params("format")))
+	return gc.sendExport(c, file, err)
+}
+
+func (gc *GameController) ExportSession(c *fiber.Ctx) error {
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok || userID == 0 {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in session"})
+	}
+
+	sessionId, err := strconv.ParseUint(c.Params("sessionId"), 10, 64)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid ID"})
+	}
+
+	file, err := gc.exportService.ExportSession(uint(sessionId), userID, service.ExportFormat(c.Params("format")))
+	return gc.sendExport(c, file, err)
+}
+
+func (gc *GameController) sendExport(c *fiber.Ctx, file *service.ExportFile, err error) error {
+	if errors.Is(err, service.ErrUnsupportedExportFormat) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Unsupported export format"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Game session not found"})
 	}
 
-	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"quiz_results_%s.csv\"", gameCode))
-	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"%s\"", file.Name))
+	c.Set(fiber.HeaderContentType, file.ContentType)
 
-	return c.Send(buf.Bytes())
+	return c.Send(file.Data)
 }
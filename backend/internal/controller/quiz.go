@@ -9,11 +9,12 @@ import (
 )
 
 type QuizController struct {
-	quizService *service.QuizService
+	quizService     *service.QuizService
+	analysisService *service.AnalysisService
 }
 
-func Quiz(svc *service.QuizService) *QuizController {
-	return &QuizController{quizService: svc}
+func Quiz(svc *service.QuizService, analysisSvc *service.AnalysisService) *QuizController {
+	return &QuizController{quizService: svc, analysisService: analysisSvc}
 }
 
 func (c *QuizController) GetQuizById(ctx *fiber.Ctx) error {
@@ -34,14 +35,37 @@ func (c *QuizController) GetQuizById(ctx *fiber.Ctx) error {
 	return ctx.JSON(quiz)
 }
 
+func (c *QuizController) GetQuizAnalysis(ctx *fiber.Ctx) error {
+	id, err := strconv.ParseUint(ctx.Params("quizId"), 10, 64)
+	if err != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid ID format. ID must be a number.",
+		})
+	}
+
+	userID, ok := ctx.Locals("user_id").(uint)
+	if !ok || userID == 0 {
+		return ctx.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "User ID not found in session"})
+	}
+
+	analysis, err := c.analysisService.AnalyzeQuiz(uint(id), userID)
+	if err != nil {
+		return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Quiz not found"})
+	}
+
+	return ctx.JSON(analysis)
+}
+
 type UpdateQuizRequest struct {
 	Name      string                `json:"name"`
 	Questions []entity.QuizQuestion `json:"questions"`
+	entity.QuizSettings
 }
 
 type CreateQuizRequest struct {
 	Name      string                `json:"name"`
 	Questions []entity.QuizQuestion `json:"questions"`
+	entity.QuizSettings
 }
 
 func (c *QuizController) UpdateQuizById(ctx *fiber.Ctx) error {
@@ -69,7 +93,7 @@ func (c *QuizController) UpdateQuizById(ctx *fiber.Ctx) error {
 		})
 	}
 
-	if err := c.quizService.UpdateQuiz(uint(quizId), uint64(userID), req.Name, req.Questions); err != nil {
+	if err := c.quizService.UpdateQuiz(uint(quizId), uint64(userID), req.Name, req.QuizSettings, req.Questions); err != nil {
 		return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": err.Error(),
 		})
@@ -89,9 +113,10 @@ func (c *QuizController) CreateQuiz(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Cannot parse body"})
 	}
 	newQuiz := entity.Quiz{
-		Name:      req.Name,
-		Questions: req.Questions,
-		UserID:    uint64(userID),
+		Name:         req.Name,
+		Questions:    req.Questions,
+		UserID:       uint64(userID),
+		QuizSettings: req.QuizSettings,
 	}
 
 	createdQuiz, err := c.quizService.CreateQuiz(newQuiz)
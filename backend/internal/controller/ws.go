@@ -10,19 +10,14 @@ import (
 	gorilla "github.com/gorilla/websocket"
 )
 
-const (
-	writeWait  = 10 * time.Second
-	pongWait   = 60 * time.Second
-	pingPeriod = (pongWait * 9) / 10
-)
-
 type WebsocketController struct {
 	netService *service.NetService
 }
 
 type CheckNamePayload struct {
-	Code string `json:"code"`
-	Name string `json:"name"`
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	ResumeToken string `json:"resumeToken"`
 }
 
 func Ws(netService *service.NetService) WebsocketController {
@@ -32,19 +27,8 @@ func Ws(netService *service.NetService) WebsocketController {
 }
 
 func (c WebsocketController) Ws(con *websocket.Conn) {
-	go func() {
-		ticker := time.NewTicker(pingPeriod)
-		defer func() {
-			ticker.Stop()
-			con.Close()
-		}()
-		for {
-			<-ticker.C
-			if err := con.WriteMessage(websocket.PingMessage, nil); err != nil {
-				return
-			}
-		}
-	}()
+	client := c.netService.Connect(con)
+	defer client.Wait()
 
 	var (
 		mt  int
@@ -52,10 +36,10 @@ func (c WebsocketController) Ws(con *websocket.Conn) {
 		err error
 	)
 
-	con.SetReadDeadline(time.Now().Add(pongWait))
+	con.SetReadDeadline(time.Now().Add(service.ClientPongWait))
 
 	con.SetPongHandler(func(appData string) error {
-		con.SetReadDeadline(time.Now().Add(pongWait))
+		con.SetReadDeadline(time.Now().Add(service.ClientPongWait))
 		return nil
 	})
 
@@ -88,6 +72,10 @@ func (w *WebsocketController) CheckPlayerName(c *fiber.Ctx) error {
 		return c.SendStatus(fiber.StatusOK)
 	}
 
+	if w.netService.CanResume(payload.Code, payload.ResumeToken) {
+		return c.SendStatus(fiber.StatusOK)
+	}
+
 	if w.netService.IsNameTakenInGame(payload.Code, payload.Name) {
 		return c.SendStatus(fiber.StatusConflict)
 	}
@@ -102,7 +90,8 @@ func (w *WebsocketController) CheckPlayerName(c *fiber.Ctx) error {
 
 func (w *WebsocketController) CheckGamePin(c *fiber.Ctx) error {
 	var payload struct {
-		Code string `json:"code"`
+		Code        string `json:"code"`
+		ResumeToken string `json:"resumeToken"`
 	}
 	if err := c.BodyParser(&payload); err != nil {
 		return c.SendStatus(fiber.StatusBadRequest)
@@ -112,6 +101,17 @@ func (w *WebsocketController) CheckGamePin(c *fiber.Ctx) error {
 		return c.SendStatus(fiber.StatusNotFound)
 	}
 
+	if w.netService.CanResume(payload.Code, payload.ResumeToken) {
+		return c.SendStatus(fiber.StatusOK)
+	}
+
+	if game.IsSelfPaced() {
+		if !game.AssignmentOpen() {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Assignment is not open"})
+		}
+		return c.SendStatus(fiber.StatusOK)
+	}
+
 	if game.State != 0 {
 		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Game has already started"})
 	}
@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"time"
+)
+
+type GameSession struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	Code          string                `json:"code"`
+	QuizID        uint                  `json:"quizId" gorm:"index"`
+	QuizName      string                `json:"quizName"`
+	HostUserID    uint64                `json:"-" gorm:"index;type:bigint"`
+	QuestionCount int                   `json:"questionCount"`
+	Questions     []GameSessionQuestion `json:"questions" gorm:"serializer:json"`
+	StartedAt     time.Time             `json:"startedAt"`
+	EndedAt       *time.Time            `json:"endedAt"`
+	Players       []GamePlayerResult    `json:"players,omitempty" gorm:"foreignKey:GameSessionID;constraint:OnDelete:CASCADE;"`
+}
+
+type GameSessionQuestion struct {
+	ID      uint         `json:"id"`
+	Name    string       `json:"name"`
+	Type    QuestionType `json:"type"`
+	Choices []string     `json:"choices"`
+}
+
+type GamePlayerResult struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+
+	GameSessionID uint         `json:"gameSessionId" gorm:"index"`
+	PlayerID      string       `json:"playerId" gorm:"index"`
+	Name          string       `json:"name"`
+	Team          int          `json:"team"`
+	Points        int          `json:"points"`
+	CorrectCount  int          `json:"correctCount"`
+	MaxStreak     int          `json:"maxStreak"`
+	Answers       []GameAnswer `json:"answers,omitempty" gorm:"foreignKey:GamePlayerResultID;constraint:OnDelete:CASCADE;"`
+}
+
+type GameAnswer struct {
+	ID        uint      `gorm:"primaryKey" json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	GameSessionID      uint       `json:"gameSessionId" gorm:"index"`
+	GamePlayerResultID uint       `json:"gamePlayerResultId" gorm:"index"`
+	QuestionIndex      int        `json:"questionIndex"`
+	QuestionID         uint       `json:"questionId" gorm:"index"`
+	Answered           bool       `json:"answered"`
+	Choices            []int      `json:"choices" gorm:"serializer:json"`
+	ChoiceIDs          []uint     `json:"choiceIds" gorm:"serializer:json"`
+	Text               string     `json:"text"`
+	Value              *float64   `json:"value"`
+	Order              []int      `json:"order" gorm:"serializer:json"`
+	Correct            bool       `json:"correct"`
+	Credit             float64    `json:"credit"`
+	Points             int        `json:"points"`
+	ResponseTimeMs     int64      `json:"responseTimeMs"`
+	AnsweredAt         *time.Time `json:"answeredAt"`
+}
@@ -15,19 +15,96 @@ type Quiz struct {
 	Name      string         `json:"name"`
 	UserID    uint64         `json:"-" gorm:"not null;type:bigint;column:user_id"`
 	Questions []QuizQuestion `json:"questions" gorm:"foreignKey:QuizID;constraint:OnDelete:CASCADE;"`
+
+	QuizSettings `gorm:"embedded"`
+}
+
+type ScoringStrategy string
+
+const (
+	StrategyRankBonus ScoringStrategy = "rank_bonus"
+	StrategyTimeDecay ScoringStrategy = "time_decay"
+	StrategyFlat      ScoringStrategy = "flat"
+	StrategyExam      ScoringStrategy = "exam"
+)
+
+type QuizSettings struct {
+	ScoringStrategy      ScoringStrategy `json:"scoringStrategy" gorm:"type:varchar(32);default:'rank_bonus'"`
+	BonusRoundMultiplier float64         `json:"bonusRoundMultiplier"`
+	ShuffleQuestions     bool            `json:"shuffleQuestions"`
+	ShuffleChoices       bool            `json:"shuffleChoices"`
 }
 
+type QuestionType string
+
+const (
+	QuestionTypeSingle    QuestionType = "single"
+	QuestionTypeMultiple  QuestionType = "multiple"
+	QuestionTypeText      QuestionType = "text"
+	QuestionTypeNumeric   QuestionType = "numeric"
+	QuestionTypeOrdering  QuestionType = "ordering"
+	QuestionTypePoll      QuestionType = "poll"
+	QuestionTypeWordCloud QuestionType = "word_cloud"
+)
+
+type ScoringMode string
+
+const (
+	ScoringAllOrNothing ScoringMode = "all_or_nothing"
+	ScoringProportional ScoringMode = "proportional"
+	ScoringPenalty      ScoringMode = "penalty"
+	ScoringExact        ScoringMode = "exact"
+	ScoringTolerance    ScoringMode = "tolerance"
+	ScoringLinear       ScoringMode = "linear"
+	ScoringPerPosition  ScoringMode = "per_position"
+)
+
 type QuizQuestion struct {
 	ID        uint           `gorm:"primaryKey" json:"id"`
 	CreatedAt time.Time      `json:"createdAt"`
 	UpdatedAt time.Time      `json:"updatedAt"`
 	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
 
-	Name     string       `json:"name"`
-	Time     int          `json:"time"`
-	ImageUrl string       `json:"imageUrl"`
-	Choices  []QuizChoice `json:"choices" gorm:"foreignKey:QuestionID"`
-	QuizID   uint
+	Name             string       `json:"name"`
+	Time             int          `json:"time"`
+	ImageUrl         string       `json:"imageUrl"`
+	Type             QuestionType `json:"type" gorm:"type:varchar(32);default:'single'"`
+	ScoringMode      ScoringMode  `json:"scoringMode" gorm:"type:varchar(32);default:'all_or_nothing'"`
+	PointsMultiplier *float64     `json:"pointsMultiplier" gorm:"default:1"`
+	Choices          []QuizChoice `json:"choices" gorm:"foreignKey:QuestionID"`
+
+	AcceptedAnswers []string         `json:"acceptedAnswers" gorm:"serializer:json"`
+	TextMatch       TextMatchOptions `json:"textMatch" gorm:"embedded;embeddedPrefix:match_"`
+	Numeric         NumericOptions   `json:"numeric" gorm:"embedded;embeddedPrefix:numeric_"`
+
+	QuizID uint
+}
+
+func (q QuizQuestion) Multiplier() float64 {
+	if q.PointsMultiplier == nil || *q.PointsMultiplier < 0 {
+		return 1
+	}
+	return *q.PointsMultiplier
+}
+
+func (q QuizQuestion) IsGraded() bool {
+	return q.Type != QuestionTypePoll && q.Type != QuestionTypeWordCloud
+}
+
+type TextMatchOptions struct {
+	CaseSensitive  bool `json:"caseSensitive"`
+	KeepWhitespace bool `json:"keepWhitespace"`
+	KeepDiacritics bool `json:"keepDiacritics"`
+	KeepToneMarks  bool `json:"keepToneMarks"`
+	MaxDistance    int  `json:"maxDistance"`
+}
+
+type NumericOptions struct {
+	Min       float64 `json:"min"`
+	Max       float64 `json:"max"`
+	Step      float64 `json:"step"`
+	Correct   float64 `json:"correct"`
+	Tolerance float64 `json:"tolerance"`
 }
 
 type QuizChoice struct {
@@ -38,6 +115,7 @@ type QuizChoice struct {
 
 	Name       string  `json:"name"`
 	Correct    bool    `json:"correct" gorm:"column:is_correct"`
+	Position   int     `json:"position"`
 	ImageUrl   *string `json:"imageUrl,omitempty"`
 	QuestionID uint
 }
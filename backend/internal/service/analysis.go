@@ -0,0 +1,241 @@
+package service
+
+import (
+	"errors"
+	"sort"
+
+	"CorrectQuiz.com/quiz/internal/entity"
+)
+
+const discriminationGroupRatio = 0.27
+
+var ErrQuizNotOwned = errors.New("quiz not found")
+
+type ChoiceAnalysis struct {
+	ChoiceID      uint    `json:"choiceId"`
+	Name          string  `json:"name"`
+	Correct       bool    `json:"correct"`
+	SelectedCount int     `json:"selectedCount"`
+	SelectionRate float64 `json:"selectionRate"`
+}
+
+type QuestionAnalysis struct {
+	QuestionID            uint                `json:"questionId"`
+	Name                  string              `json:"name"`
+	Type                  entity.QuestionType `json:"type"`
+	Graded                bool                `json:"graded"`
+	Presented             int                 `json:"presented"`
+	Answered              int                 `json:"answered"`
+	PercentCorrect        float64             `json:"percentCorrect"`
+	AverageCredit         float64             `json:"averageCredit"`
+	AverageResponseTimeMs float64             `json:"averageResponseTimeMs"`
+	DiscriminationIndex   *float64            `json:"discriminationIndex"`
+	Choices               []ChoiceAnalysis    `json:"choices,omitempty"`
+}
+
+type QuizAnalysis struct {
+	QuizID       uint               `json:"quizId"`
+	QuizName     string             `json:"quizName"`
+	SessionCount int                `json:"sessionCount"`
+	PlayerCount  int                `json:"playerCount"`
+	Questions    []QuestionAnalysis `json:"questions"`
+}
+
+type AnalysisService struct {
+	quizService    *QuizService
+	sessionService *GameSessionService
+}
+
+func NewAnalysisService(quizService *QuizService, sessionService *GameSessionService) *AnalysisService {
+	return &AnalysisService{
+		quizService:    quizService,
+		sessionService: sessionService,
+	}
+}
+
+func (s *AnalysisService) AnalyzeQuiz(quizID uint, userID uint) (*QuizAnalysis, error) {
+	quiz, err := s.quizService.GetQuizById(quizID)
+	if err != nil {
+		return nil, err
+	}
+	if quiz.UserID != uint64(userID) {
+		return nil, ErrQuizNotOwned
+	}
+
+	sessions, err := s.sessionService.GetSessionsByQuiz(quizID)
+	if err != nil {
+		return nil, err
+	}
+	return analyzeSessions(*quiz, sessions), nil
+}
+
+func analyzeSessions(quiz entity.Quiz, sessions []entity.GameSession) *QuizAnalysis {
+	players := []entity.GamePlayerResult{}
+	for _, session := range sessions {
+		players = append(players, session.Players...)
+	}
+	top, bottom := discriminationGroups(players, quiz.Questions)
+
+	analysis := &QuizAnalysis{
+		QuizID:       quiz.ID,
+		QuizName:     quiz.Name,
+		SessionCount: len(sessions),
+		PlayerCount:  len(players),
+		Questions:    make([]QuestionAnalysis, 0, len(quiz.Questions)),
+	}
+	for _, question := range quiz.Questions {
+		analysis.Questions = append(analysis.Questions, analyzeQuestion(question, players, top, bottom))
+	}
+	return analysis
+}
+
+// Points are not comparable across sessions with different scoring strategies
+// and multipliers, so players are ranked on their average graded credit.
+func discriminationGroups(players []entity.GamePlayerResult, questions []entity.QuizQuestion) ([]entity.GamePlayerResult, []entity.GamePlayerResult) {
+	graded := make(map[uint]bool, len(questions))
+	for _, question := range questions {
+		if question.IsGraded() {
+			graded[question.ID] = true
+		}
+	}
+
+	type scoredPlayer struct {
+		player entity.GamePlayerResult
+		credit float64
+	}
+	scored := make([]scoredPlayer, 0, len(players))
+	for _, player := range players {
+		if credit, ok := averageGradedCredit(player, graded); ok {
+			scored = append(scored, scoredPlayer{player: player, credit: credit})
+		}
+	}
+	if len(scored) < 2 {
+		return nil, nil
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].credit > scored[j].credit
+	})
+	ranked := make([]entity.GamePlayerResult, len(scored))
+	for i, entry := range scored {
+		ranked[i] = entry.player
+	}
+
+	size := int(float64(len(ranked)) * discriminationGroupRatio)
+	if size < 1 {
+		size = 1
+	}
+	return ranked[:size], ranked[len(ranked)-size:]
+}
+
+func averageGradedCredit(player entity.GamePlayerResult, graded map[uint]bool) (float64, bool) {
+	presented := 0
+	credit := 0.0
+	for _, answer := range player.Answers {
+		if !graded[answer.QuestionID] {
+			continue
+		}
+		presented++
+		credit += answer.Credit
+	}
+	if presented == 0 {
+		return 0, false
+	}
+	return credit / float64(presented), true
+}
+
+func findQuestionAnswer(player entity.GamePlayerResult, questionID uint) (entity.GameAnswer, bool) {
+	for _, answer := range player.Answers {
+		if answer.QuestionID == questionID {
+			return answer, true
+		}
+	}
+	return entity.GameAnswer{}, false
+}
+
+func groupCredit(players []entity.GamePlayerResult, questionID uint) (float64, bool) {
+	presented := 0
+	credit := 0.0
+	for _, player := range players {
+		answer, ok := findQuestionAnswer(player, questionID)
+		if !ok {
+			continue
+		}
+		presented++
+		credit += answer.Credit
+	}
+	if presented == 0 {
+		return 0, false
+	}
+	return credit / float64(presented), true
+}
+
+func analyzeQuestion(question entity.QuizQuestion, players, top, bottom []entity.GamePlayerResult) QuestionAnalysis {
+	result := QuestionAnalysis{
+		QuestionID: question.ID,
+		Name:       question.Name,
+		Type:       question.Type,
+		Graded:     question.IsGraded(),
+	}
+
+	choiceIndexes := make(map[uint]int, len(question.Choices))
+	for i, choice := range question.Choices {
+		choiceIndexes[choice.ID] = i
+		result.Choices = append(result.Choices, ChoiceAnalysis{
+			ChoiceID: choice.ID,
+			Name:     choice.Name,
+			Correct:  choice.Correct,
+		})
+	}
+
+	correct := 0
+	credit := 0.0
+	var responseTime int64
+	for _, player := range players {
+		answer, ok := findQuestionAnswer(player, question.ID)
+		if !ok {
+			continue
+		}
+		result.Presented++
+		credit += answer.Credit
+		if answer.Correct {
+			correct++
+		}
+		if !answer.Answered {
+			continue
+		}
+		result.Answered++
+		responseTime += answer.ResponseTimeMs
+		for _, choiceID := range answer.ChoiceIDs {
+			if i, ok := choiceIndexes[choiceID]; ok {
+				result.Choices[i].SelectedCount++
+			}
+		}
+	}
+
+	if result.Presented > 0 {
+		result.PercentCorrect = float64(correct) / float64(result.Presented) * 100
+		result.AverageCredit = credit / float64(result.Presented)
+	}
+	if result.Answered > 0 {
+		result.AverageResponseTimeMs = float64(responseTime) / float64(result.Answered)
+		for i := range result.Choices {
+			result.Choices[i].SelectionRate = float64(result.Choices[i].SelectedCount) / float64(result.Answered) * 100
+		}
+	}
+
+	if result.Graded {
+		topCredit, topOk := groupCredit(top, question.ID)
+		bottomCredit, bottomOk := groupCredit(bottom, question.ID)
+		if topOk && bottomOk {
+			index := topCredit - bottomCredit
+			result.DiscriminationIndex = &index
+		}
+	}
+
+	switch question.Type {
+	case entity.QuestionTypeText, entity.QuestionTypeNumeric, entity.QuestionTypeOrdering, entity.QuestionTypeWordCloud:
+		result.Choices = nil
+	}
+	return result
+}
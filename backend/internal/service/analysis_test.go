@@ -0,0 +1,157 @@
+package service
+
+import (
+	"math"
+	"testing"
+
+	"CorrectQuiz.com/quiz/internal/entity"
+)
+
+func analysisTestQuiz() entity.Quiz {
+	return entity.Quiz{
+		Name: "analysis",
+		Questions: []entity.QuizQuestion{
+			{ID: 1, Name: "Q1", Type: entity.QuestionTypeSingle, Choices: []entity.QuizChoice{
+				{ID: 11, Name: "right", Correct: true},
+				{ID: 12, Name: "wrong"},
+			}},
+			{ID: 2, Name: "Q2", Type: entity.QuestionTypeSingle, Choices: []entity.QuizChoice{
+				{ID: 21, Name: "right", Correct: true},
+				{ID: 22, Name: "wrong"},
+			}},
+			{ID: 3, Name: "Q3", Type: entity.QuestionTypePoll, Choices: []entity.QuizChoice{
+				{ID: 31, Name: "yes"},
+				{ID: 32, Name: "no"},
+			}},
+		},
+	}
+}
+
+func analysisAnswer(questionID uint, correct bool) entity.GameAnswer {
+	answer := entity.GameAnswer{
+		QuestionID:     questionID,
+		Answered:       true,
+		ChoiceIDs:      []uint{questionID*10 + 2},
+		ResponseTimeMs: 2000,
+	}
+	if correct {
+		answer.Correct = true
+		answer.Credit = 1
+		answer.ChoiceIDs = []uint{questionID*10 + 1}
+	}
+	return answer
+}
+
+func analysisPlayer(name string, points int, q1 bool, q2 bool) entity.GamePlayerResult {
+	return entity.GamePlayerResult{
+		Name:    name,
+		Points:  points,
+		Answers: []entity.GameAnswer{analysisAnswer(1, q1), analysisAnswer(2, q2)},
+	}
+}
+
+// Session A used a generous strategy, so its players out-point session B's
+// players even though they answered fewer questions correctly.
+func analysisTestSessions() []entity.GameSession {
+	generous := entity.GameSession{Players: []entity.GamePlayerResult{
+		analysisPlayer("a1", 1200, false, true),
+		analysisPlayer("a2", 1000, false, false),
+		analysisPlayer("a3", 900, true, false),
+		analysisPlayer("a4", 800, false, false),
+	}}
+	flat := entity.GameSession{Players: []entity.GamePlayerResult{
+		analysisPlayer("b1", 200, true, true),
+		analysisPlayer("b2", 190, true, true),
+		analysisPlayer("b3", 100, false, true),
+		analysisPlayer("b4", 0, false, false),
+	}}
+
+	generous.Players[0].Answers = append(generous.Players[0].Answers, entity.GameAnswer{QuestionID: 3, Answered: true, ChoiceIDs: []uint{31}, ResponseTimeMs: 1000})
+	flat.Players[0].Answers = append(flat.Players[0].Answers, entity.GameAnswer{QuestionID: 3, Answered: true, ChoiceIDs: []uint{32}, ResponseTimeMs: 3000})
+	flat.Players[0].Answers[0].ResponseTimeMs = 4000
+	flat.Players[3].Answers[1] = entity.GameAnswer{QuestionID: 2}
+
+	return []entity.GameSession{generous, flat}
+}
+
+func assertClose(t *testing.T, name string, got float64, want float64) {
+	t.Helper()
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("%s = %v, want %v", name, got, want)
+	}
+}
+
+func TestAnalyzeSessionsDifficulty(t *testing.T) {
+	analysis := analyzeSessions(analysisTestQuiz(), analysisTestSessions())
+
+	if analysis.SessionCount != 2 || analysis.PlayerCount != 8 {
+		t.Fatalf("sessions = %d players = %d, want 2 and 8", analysis.SessionCount, analysis.PlayerCount)
+	}
+
+	q1 := analysis.Questions[0]
+	if q1.Presented != 8 || q1.Answered != 8 {
+		t.Errorf("Q1 presented %d answered %d, want 8 and 8", q1.Presented, q1.Answered)
+	}
+	assertClose(t, "Q1 percent correct", q1.PercentCorrect, 37.5)
+	assertClose(t, "Q1 average credit", q1.AverageCredit, 0.375)
+	assertClose(t, "Q1 average response time", q1.AverageResponseTimeMs, 2250)
+	assertClose(t, "Q1 right selection rate", q1.Choices[0].SelectionRate, 37.5)
+	assertClose(t, "Q1 wrong selection rate", q1.Choices[1].SelectionRate, 62.5)
+
+	q2 := analysis.Questions[1]
+	if q2.Presented != 8 || q2.Answered != 7 {
+		t.Errorf("Q2 presented %d answered %d, want 8 and 7", q2.Presented, q2.Answered)
+	}
+	assertClose(t, "Q2 percent correct", q2.PercentCorrect, 50)
+	assertClose(t, "Q2 right selection rate", q2.Choices[0].SelectionRate, 4.0/7*100)
+
+	q3 := analysis.Questions[2]
+	if q3.Graded || q3.DiscriminationIndex != nil {
+		t.Errorf("poll Q3 graded = %v discrimination = %v, want ungraded with no index", q3.Graded, q3.DiscriminationIndex)
+	}
+	if q3.Choices[0].SelectedCount != 1 || q3.Choices[1].SelectedCount != 1 {
+		t.Errorf("Q3 choices = %+v, want one vote each", q3.Choices)
+	}
+	assertClose(t, "Q3 average response time", q3.AverageResponseTimeMs, 2000)
+}
+
+func TestAnalyzeSessionsDiscriminationUsesCredit(t *testing.T) {
+	analysis := analyzeSessions(analysisTestQuiz(), analysisTestSessions())
+
+	for i, want := range []float64{1, 1} {
+		index := analysis.Questions[i].DiscriminationIndex
+		if index == nil {
+			t.Fatalf("Q%d has no discrimination index", i+1)
+		}
+		assertClose(t, analysis.Questions[i].Name+" discrimination", *index, want)
+	}
+}
+
+func TestDiscriminationGroupsRankOnCredit(t *testing.T) {
+	players := []entity.GamePlayerResult{}
+	for _, session := range analysisTestSessions() {
+		players = append(players, session.Players...)
+	}
+	players = append(players, entity.GamePlayerResult{
+		Name:    "poll only",
+		Points:  5000,
+		Answers: []entity.GameAnswer{{QuestionID: 3, Answered: true}},
+	})
+
+	top, bottom := discriminationGroups(players, analysisTestQuiz().Questions)
+	if len(top) != 2 || len(bottom) != 2 {
+		t.Fatalf("got %d top and %d bottom players, want 2 each", len(top), len(bottom))
+	}
+	if top[0].Name != "b1" || top[1].Name != "b2" {
+		t.Errorf("top group = %s, %s, want b1, b2", top[0].Name, top[1].Name)
+	}
+	for _, player := range bottom {
+		if credit, _ := averageGradedCredit(player, map[uint]bool{1: true, 2: true}); credit != 0 {
+			t.Errorf("bottom group has %s with credit %v", player.Name, credit)
+		}
+	}
+
+	if top, bottom := discriminationGroups(players[:1], analysisTestQuiz().Questions); top != nil || bottom != nil {
+		t.Error("a single player should not form discrimination groups")
+	}
+}
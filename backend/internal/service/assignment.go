@@ -0,0 +1,324 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"math"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+)
+
+const AssignmentReconnectGrace = 15 * time.Minute
+
+var (
+	ErrAssignmentWindow   = errors.New("assignment must close after it opens and in the future")
+	ErrAssignmentNotFound = errors.New("assignment not found")
+	ErrQuizHasNoQuestions = errors.New("quiz has no questions")
+)
+
+type AssignmentOptions struct {
+	OpensAt  time.Time `json:"opensAt"`
+	ClosesAt time.Time `json:"closesAt"`
+}
+
+type AssignmentInfo struct {
+	Code     string    `json:"code"`
+	QuizID   uint      `json:"quizId"`
+	QuizName string    `json:"quizName"`
+	OpensAt  time.Time `json:"opensAt"`
+	ClosesAt time.Time `json:"closesAt"`
+	Players  int       `json:"players"`
+	Finished int       `json:"finished"`
+}
+
+func (o *AssignmentOptions) valid(now time.Time) bool {
+	return o.ClosesAt.After(o.OpensAt) && o.ClosesAt.After(now)
+}
+
+func (o *AssignmentOptions) isOpen(now time.Time) bool {
+	return !now.Before(o.OpensAt) && now.Before(o.ClosesAt)
+}
+
+func (g *Game) IsSelfPaced() bool {
+	return g.Assignment != nil
+}
+
+func (g *Game) AssignmentOpen() bool {
+	return g.IsSelfPaced() && g.Assignment.isOpen(time.Now())
+}
+
+func (g *Game) playerReconnectGrace() time.Duration {
+	if g.IsSelfPaced() {
+		return AssignmentReconnectGrace
+	}
+	return PlayerReconnectGrace
+}
+
+func (g *Game) assignmentInfo() AssignmentInfo {
+	g.playersMutex.RLock()
+	defer g.playersMutex.RUnlock()
+
+	finished := 0
+	players := append(append([]*Player{}, g.Players...), g.departedPlayers...)
+	for _, player := range players {
+		if player.finished {
+			finished++
+		}
+	}
+
+	return AssignmentInfo{
+		Code:     g.Code,
+		QuizID:   g.Quiz.ID,
+		QuizName: g.Quiz.Name,
+		OpensAt:  g.Assignment.OpensAt,
+		ClosesAt: g.Assignment.ClosesAt,
+		Players:  len(players),
+		Finished: finished,
+	}
+}
+
+func (c *NetService) PublishAssignment(quizID uint, userID uint, options AssignmentOptions) (*AssignmentInfo, error) {
+	quiz, err := c.quizService.GetQuizById(quizID)
+	if err != nil {
+		return nil, err
+	}
+	if quiz.UserID != uint64(userID) {
+		return nil, ErrQuizNotOwned
+	}
+	if len(quiz.Questions) == 0 {
+		return nil, ErrQuizHasNoQuestions
+	}
+
+	now := time.Now()
+	if options.OpensAt.IsZero() {
+		options.OpensAt = now
+	}
+	if !options.valid(now) {
+		return nil, ErrAssignmentWindow
+	}
+
+	game := newGame(*quiz, nil, c)
+	game.Assignment = &options
+	game.State = PlayState
+	game.selfPacedCorrect = make(map[int]int)
+	game.shuffleQuestions()
+
+	if err := c.games.Register(&game); err != nil {
+		return nil, err
+	}
+
+	game.startSession()
+	game.closeTimer = time.AfterFunc(options.ClosesAt.Sub(now), func() {
+		log.Printf("Game %s: assignment closed", game.Code)
+		c.endGame(&game)
+	})
+
+	log.Printf("📚 Assignment %s published for quiz %d (%s - %s)", game.Code, quiz.ID, options.OpensAt.Format(time.RFC3339), options.ClosesAt.Format(time.RFC3339))
+	info := game.assignmentInfo()
+	return &info, nil
+}
+
+func (c *NetService) AssignmentsByOwner(userID uint) []AssignmentInfo {
+	assignments := []AssignmentInfo{}
+	for _, game := range c.games.All() {
+		if game.IsSelfPaced() && game.Quiz.UserID == uint64(userID) {
+			assignments = append(assignments, game.assignmentInfo())
+		}
+	}
+	return assignments
+}
+
+func (c *NetService) CloseAssignment(code string, userID uint) error {
+	game := c.GetGameByCode(code)
+	if game == nil || !game.IsSelfPaced() || game.Quiz.UserID != uint64(userID) {
+		return ErrAssignmentNotFound
+	}
+
+	if game.closeTimer != nil {
+		game.closeTimer.Stop()
+	}
+	c.endGame(game)
+	return nil
+}
+
+func (g *Game) assignmentClosed() bool {
+	return g.ctx.Err() != nil
+}
+
+func (g *Game) showSelfPacedQuestion(player *Player) {
+	g.playersMutex.Lock()
+	if player.finished || g.assignmentClosed() {
+		g.playersMutex.Unlock()
+		return
+	}
+
+	questionIndex := player.questionIndex
+	limit := questionTimeLimit(g.Quiz.Questions[questionIndex])
+
+	player.Answered = false
+	player.CurrentAnswer = PlayerAnswer{}
+	player.awaitingNext = false
+	player.questionStartedAt = time.Now()
+	if player.questionTimer != nil {
+		player.questionTimer.Stop()
+	}
+	player.questionTimer = time.AfterFunc(time.Duration(limit)*time.Second, func() {
+		g.expireSelfPacedQuestion(player, questionIndex)
+	})
+	connection := player.Connection
+	g.playersMutex.Unlock()
+
+	g.sendSelfPacedQuestion(player, connection, questionIndex, limit)
+}
+
+func (g *Game) sendSelfPacedQuestion(player *Player, connection *websocket.Conn, questionIndex int, remaining int) {
+	showPacket := g.playerQuestionShowPacket(player, questionIndex)
+	showPacket.SelfPaced = true
+
+	g.netService.SendPacket(connection, ChangeGameStatePacket{
+		State: PlayState,
+	})
+	g.netService.SendPacket(connection, showPacket)
+	g.netService.SendPacket(connection, TickPacket{
+		Tick: remaining,
+	})
+}
+
+func (g *Game) onSelfPacedAnswer(questionIndex int, answer PlayerAnswer, player *Player) {
+	g.playersMutex.Lock()
+	if player.Answered || player.awaitingNext || player.finished || questionIndex != player.questionIndex || g.assignmentClosed() {
+		g.playersMutex.Unlock()
+		return
+	}
+
+	question := g.Quiz.Questions[questionIndex]
+	elapsed := time.Since(player.questionStartedAt)
+	limit := time.Duration(questionTimeLimit(question)) * time.Second
+	if elapsed >= limit {
+		g.playersMutex.Unlock()
+		return
+	}
+
+	if player.questionTimer != nil {
+		player.questionTimer.Stop()
+	}
+	player.Answered = true
+	player.CurrentAnswer = g.canonicalAnswerLocked(player, questionIndex, answer)
+	player.answeredAt = time.Now()
+	player.AnswerTimeRemaining = int(math.Ceil((limit - elapsed).Seconds()))
+	g.playersMutex.Unlock()
+
+	g.revealSelfPacedQuestion(player, questionIndex)
+}
+
+func (g *Game) expireSelfPacedQuestion(player *Player, questionIndex int) {
+	g.playersMutex.RLock()
+	expired := !player.Answered && !player.awaitingNext && !player.finished && player.questionIndex == questionIndex
+	g.playersMutex.RUnlock()
+
+	if !expired || g.assignmentClosed() {
+		return
+	}
+	g.revealSelfPacedQuestion(player, questionIndex)
+}
+
+func (g *Game) revealSelfPacedQuestion(player *Player, questionIndex int) {
+	question := g.Quiz.Questions[questionIndex]
+
+	g.playersMutex.Lock()
+	if player.awaitingNext || player.questionIndex != questionIndex {
+		g.playersMutex.Unlock()
+		return
+	}
+	player.awaitingNext = true
+
+	credit := 0.0
+	if player.Answered && question.IsGraded() {
+		credit = gradeAnswer(question, player.CurrentAnswer)
+	}
+	rank := -1
+	if credit >= 1 {
+		rank = g.selfPacedCorrect[questionIndex]
+		g.selfPacedCorrect[questionIndex]++
+	}
+
+	record, feedbackPacket, revealPacket := g.scorePlayerLocked(player, questionIndex, credit, rank)
+	record.startedAt = player.questionStartedAt
+	connection := player.Connection
+	g.playersMutex.Unlock()
+
+	g.netService.SendPacket(connection, feedbackPacket)
+	g.netService.SendPacket(connection, revealPacket)
+
+	g.recordQuestion(questionIndex, []answerRecord{record})
+}
+
+func (g *Game) AdvanceSelfPaced(player *Player) {
+	g.playersMutex.Lock()
+	if !player.awaitingNext || player.finished {
+		g.playersMutex.Unlock()
+		return
+	}
+
+	if player.questionIndex >= len(g.Quiz.Questions)-1 {
+		player.finished = true
+		g.playersMutex.Unlock()
+		g.finishSelfPaced(player)
+		return
+	}
+
+	player.questionIndex++
+	g.playersMutex.Unlock()
+
+	g.showSelfPacedQuestion(player)
+}
+
+func (g *Game) finishSelfPaced(player *Player) {
+	leaderboardData := g.getLeaderboard()
+	rank := 0
+	for i, entry := range leaderboardData {
+		if entry.PlayerId == player.Id {
+			rank = i + 1
+			break
+		}
+	}
+
+	g.playersMutex.RLock()
+	connection := player.Connection
+	team := player.Team
+	g.playersMutex.RUnlock()
+
+	standings := g.teamStandings()
+	g.netService.SendPacket(connection, ChangeGameStatePacket{
+		State: EndState,
+	})
+	g.netService.SendPacket(connection, LeaderboardPacket{
+		Points: leaderboardData,
+		Teams:  standings,
+	})
+	g.netService.SendPacket(connection, PlayerRankPacket{
+		Rank:     rank,
+		Team:     team,
+		TeamRank: teamRanks(standings)[team],
+	})
+}
+
+func (g *Game) resumeSelfPaced(player *Player) {
+	g.playersMutex.RLock()
+	finished := player.finished
+	awaitingNext := player.awaitingNext
+	questionIndex := player.questionIndex
+	remaining := questionTimeLimit(g.Quiz.Questions[questionIndex]) - int(time.Since(player.questionStartedAt).Seconds())
+	connection := player.Connection
+	g.playersMutex.RUnlock()
+
+	switch {
+	case finished:
+		g.finishSelfPaced(player)
+	case awaitingNext:
+		g.AdvanceSelfPaced(player)
+	case remaining > 0:
+		g.sendSelfPacedQuestion(player, connection, questionIndex, remaining)
+	}
+}
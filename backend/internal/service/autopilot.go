@@ -0,0 +1,44 @@
+package service
+
+const (
+	defaultAutoPilotRevealSeconds       = 8
+	defaultAutoPilotIntermissionSeconds = 6
+	maxAutoPilotSeconds                 = 120
+)
+
+type AutoPilotOptions struct {
+	RevealSeconds       int `json:"revealSeconds"`
+	IntermissionSeconds int `json:"intermissionSeconds"`
+}
+
+func (o *AutoPilotOptions) normalize() *AutoPilotOptions {
+	if o == nil {
+		return nil
+	}
+	normalized := *o
+	normalized.RevealSeconds = clampAutoPilotSeconds(o.RevealSeconds, defaultAutoPilotRevealSeconds)
+	normalized.IntermissionSeconds = clampAutoPilotSeconds(o.IntermissionSeconds, defaultAutoPilotIntermissionSeconds)
+	return &normalized
+}
+
+func clampAutoPilotSeconds(seconds int, fallback int) int {
+	if seconds <= 0 {
+		return fallback
+	}
+	if seconds > maxAutoPilotSeconds {
+		return maxAutoPilotSeconds
+	}
+	return seconds
+}
+
+func (g *Game) autoPilotStep() {
+	switch g.State {
+	case RevealState:
+		g.Intermission()
+		if g.State == IntermissionState {
+			g.Time = g.AutoPilot.IntermissionSeconds
+		}
+	case IntermissionState:
+		g.NextQuestion()
+	}
+}
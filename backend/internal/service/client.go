@@ -0,0 +1,143 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+)
+
+const (
+	ClientPongWait     = 60 * time.Second
+	clientPingPeriod   = (ClientPongWait * 9) / 10
+	clientWriteWait    = 10 * time.Second
+	clientSendBuffer   = 256
+	clientCloseMessage = -1
+)
+
+var (
+	ErrClientClosed = errors.New("connection is closed")
+	ErrSlowConsumer = errors.New("client is not keeping up with outgoing packets")
+)
+
+type outboundMessage struct {
+	messageType int
+	data        []byte
+}
+
+type Client struct {
+	connection *websocket.Conn
+	userID     uint
+
+	negotiated   bool
+	version      int
+	capabilities []string
+	codecMutex   sync.RWMutex
+	codec        Codec
+	send         chan outboundMessage
+	done         chan struct{}
+	stopped      chan struct{}
+	closeOnce    sync.Once
+}
+
+func newClient(connection *websocket.Conn) *Client {
+	userID, _ := connection.Locals("user_id").(uint)
+	return &Client{
+		connection: connection,
+		userID:     userID,
+		codec:      JSONCodec,
+		send:       make(chan outboundMessage, clientSendBuffer),
+		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+}
+
+func (cl *Client) enqueue(message outboundMessage) error {
+	select {
+	case <-cl.done:
+		return ErrClientClosed
+	default:
+	}
+
+	select {
+	case cl.send <- message:
+		return nil
+	case <-cl.done:
+		return ErrClientClosed
+	default:
+		log.Printf("🐢 Slow consumer %s: %d packets queued, disconnecting", cl.connection.RemoteAddr(), len(cl.send))
+		cl.Close()
+		return ErrSlowConsumer
+	}
+}
+
+func (cl *Client) Send(data []byte) error {
+	return cl.enqueue(outboundMessage{
+		messageType: websocket.BinaryMessage,
+		data:        data,
+	})
+}
+
+func (cl *Client) CloseGracefully() {
+	if err := cl.enqueue(outboundMessage{messageType: clientCloseMessage}); err != nil {
+		cl.Close()
+	}
+}
+
+func (cl *Client) Close() {
+	cl.closeOnce.Do(func() {
+		close(cl.done)
+		cl.connection.Close()
+	})
+}
+
+func (cl *Client) Codec() Codec {
+	cl.codecMutex.RLock()
+	defer cl.codecMutex.RUnlock()
+	return cl.codec
+}
+
+func (cl *Client) setCodec(codec Codec) {
+	cl.codecMutex.Lock()
+	cl.codec = codec
+	cl.codecMutex.Unlock()
+}
+
+func (cl *Client) Wait() {
+	<-cl.stopped
+}
+
+func (cl *Client) write(messageType int, data []byte) error {
+	cl.connection.SetWriteDeadline(time.Now().Add(clientWriteWait))
+	return cl.connection.WriteMessage(messageType, data)
+}
+
+func (cl *Client) writePump() {
+	ticker := time.NewTicker(clientPingPeriod)
+	defer func() {
+		ticker.Stop()
+		cl.Close()
+		close(cl.stopped)
+	}()
+
+	for {
+		select {
+		case message := <-cl.send:
+			if message.messageType == clientCloseMessage {
+				cl.write(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+				return
+			}
+			if err := cl.write(message.messageType, message.data); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := cl.write(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-cl.done:
+			return
+		}
+	}
+}
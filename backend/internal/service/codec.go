@@ -0,0 +1,91 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+
+	"github.com/google/uuid"
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+const (
+	CodecJSON    = "json"
+	CodecMsgPack = "msgpack"
+)
+
+type Codec interface {
+	Name() string
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string {
+	return CodecJSON
+}
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+type msgPackCodec struct{}
+
+func (msgPackCodec) Name() string {
+	return CodecMsgPack
+}
+
+func (msgPackCodec) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := msgpack.NewEncoder(&buf)
+	encoder.SetCustomStructTag("json")
+	encoder.UseCompactInts(true)
+	encoder.UseCompactFloats(true)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (msgPackCodec) Unmarshal(data []byte, v any) error {
+	decoder := msgpack.NewDecoder(bytes.NewReader(data))
+	decoder.SetCustomStructTag("json")
+	return decoder.Decode(v)
+}
+
+var (
+	JSONCodec    Codec = jsonCodec{}
+	MsgPackCodec Codec = msgPackCodec{}
+)
+
+func init() {
+	msgpack.Register(uuid.UUID{}, func(encoder *msgpack.Encoder, value reflect.Value) error {
+		return encoder.EncodeString(value.Interface().(uuid.UUID).String())
+	}, func(decoder *msgpack.Decoder, value reflect.Value) error {
+		text, err := decoder.DecodeString()
+		if err != nil {
+			return err
+		}
+		id, err := uuid.Parse(text)
+		if err != nil {
+			return err
+		}
+		value.Set(reflect.ValueOf(id))
+		return nil
+	})
+}
+
+// The web frontend only speaks JSON and never offers msgpack; other clients can opt in via hello.
+func negotiateCodec(capabilities []string) Codec {
+	for _, capability := range capabilities {
+		if capability == CapabilityMsgPack {
+			return MsgPackCodec
+		}
+	}
+	return JSONCodec
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"testing"
+
+	"CorrectQuiz.com/quiz/internal/entity"
+	"github.com/gofiber/contrib/websocket"
+	"github.com/google/uuid"
+)
+
+const broadcastBenchmarkPlayers = 300
+
+func TestMsgPackCodecUsesJSONFieldNames(t *testing.T) {
+	playerId := uuid.New()
+	data, err := MsgPackCodec.Marshal(PlayerLeavePacket{PlayerId: playerId})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded map[string]any
+	if err := MsgPackCodec.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded["playerId"] != playerId.String() {
+		t.Errorf("decoded %v, want playerId %s", decoded, playerId)
+	}
+
+	var packet PlayerLeavePacket
+	if err := MsgPackCodec.Unmarshal(data, &packet); err != nil {
+		t.Fatal(err)
+	}
+	if packet.PlayerId != playerId {
+		t.Errorf("round trip gave %s, want %s", packet.PlayerId, playerId)
+	}
+}
+
+func benchmarkQuestionShowPacket() QuestionShowPacket {
+	question := leakTestQuestion(entity.QuestionTypeSingle)
+	question.Name = "ข้อใดคือเมืองหลวงของประเทศไทย"
+	return QuestionShowPacket{
+		Question:         newPlayerQuestion(question),
+		QuestionIndex:    3,
+		PointsMultiplier: 1,
+	}
+}
+
+func benchmarkBroadcast(b *testing.B, codec Codec, packet any) {
+	netService, err := Net(nil, nil, DefaultCodeOptions())
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	connections := make([]*websocket.Conn, 0, broadcastBenchmarkPlayers)
+	clients := make([]*Client, 0, broadcastBenchmarkPlayers)
+	for i := 0; i < broadcastBenchmarkPlayers; i++ {
+		connection, client := captureConnection(netService)
+		client.codec = codec
+		connections = append(connections, connection)
+		clients = append(clients, client)
+	}
+
+	bytesPerBroadcast := 0
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		netService.SendPacketToAll(connections, packet)
+
+		bytesPerBroadcast = 0
+		for _, client := range clients {
+			for _, data := range drainPackets(client) {
+				bytesPerBroadcast += len(data)
+			}
+		}
+	}
+	b.ReportMetric(float64(bytesPerBroadcast), "bytes/broadcast")
+	b.ReportMetric(float64(bytesPerBroadcast)/broadcastBenchmarkPlayers, "bytes/client")
+}
+
+func BenchmarkBroadcastQuestionShow(b *testing.B) {
+	packet := benchmarkQuestionShowPacket()
+	b.Run("json", func(b *testing.B) {
+		benchmarkBroadcast(b, JSONCodec, packet)
+	})
+	b.Run("msgpack", func(b *testing.B) {
+		benchmarkBroadcast(b, MsgPackCodec, packet)
+	})
+}
+
+func BenchmarkBroadcastTick(b *testing.B) {
+	packet := TickPacket{Tick: 17}
+	b.Run("json", func(b *testing.B) {
+		benchmarkBroadcast(b, JSONCodec, packet)
+	})
+	b.Run("msgpack", func(b *testing.B) {
+		benchmarkBroadcast(b, MsgPackCodec, packet)
+	})
+}
@@ -0,0 +1,335 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"CorrectQuiz.com/quiz/internal/entity"
+	"github.com/xuri/excelize/v2"
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatCSV  ExportFormat = "csv"
+	ExportFormatXLSX ExportFormat = "xlsx"
+)
+
+const (
+	exportSummarySheet = "Summary"
+	exportAnswersSheet = "Answers"
+	exportNoAnswer     = "-"
+)
+
+var ErrUnsupportedExportFormat = errors.New("unsupported export format")
+
+type ExportFile struct {
+	Name        string
+	ContentType string
+	Data        []byte
+}
+
+type ExportService struct {
+	sessionService *GameSessionService
+}
+
+func NewExportService(sessionService *GameSessionService) *ExportService {
+	return &ExportService{
+		sessionService: sessionService,
+	}
+}
+
+func (s *ExportService) ExportSession(sessionID uint, hostUserID uint, format ExportFormat) (*ExportFile, error) {
+	session, err := s.sessionService.GetSessionForHost(sessionID, hostUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.export(session, format)
+}
+
+func (s *ExportService) ExportLatestSession(code string, hostUserID uint, format ExportFormat) (*ExportFile, error) {
+	session, err := s.sessionService.GetLatestSessionForHost(code, hostUserID)
+	if err != nil {
+		return nil, err
+	}
+	return s.export(session, format)
+}
+
+func (s *ExportService) export(session *entity.GameSession, format ExportFormat) (*ExportFile, error) {
+	summary := exportSummaryRows(session)
+	answers := exportAnswerRows(session)
+	name := fmt.Sprintf("quiz_results_%s_%d", session.Code, session.ID)
+
+	switch format {
+	case ExportFormatCSV:
+		data, err := writeExportCSVArchive(map[string][][]string{
+			name + "_summary.csv": summary,
+			name + "_answers.csv": answers,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &ExportFile{
+			Name:        name + ".zip",
+			ContentType: "application/zip",
+			Data:        data,
+		}, nil
+	case ExportFormatXLSX:
+		data, err := writeExportXLSX(summary, answers)
+		if err != nil {
+			return nil, err
+		}
+		return &ExportFile{
+			Name:        name + ".xlsx",
+			ContentType: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			Data:        data,
+		}, nil
+	}
+	return nil, ErrUnsupportedExportFormat
+}
+
+func sessionHasTeams(session *entity.GameSession) bool {
+	for _, player := range session.Players {
+		if player.Team > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func exportSummaryRows(session *entity.GameSession) [][]string {
+	hasTeams := sessionHasTeams(session)
+
+	header := []string{"Rank", "Player Name"}
+	if hasTeams {
+		header = append(header, "Team")
+	}
+	header = append(header, "Final Score", "Correct Answers", "Max Streak", "Answered")
+	rows := [][]string{header}
+
+	rank := 0
+	for i, player := range session.Players {
+		if i == 0 || player.Points != session.Players[i-1].Points {
+			rank = i + 1
+		}
+
+		answered := 0
+		for _, answer := range player.Answers {
+			if answer.Answered {
+				answered++
+			}
+		}
+
+		row := []string{strconv.Itoa(rank), escapeFormula(player.Name)}
+		if hasTeams {
+			team := ""
+			if player.Team > 0 {
+				team = TeamName(player.Team)
+			}
+			row = append(row, team)
+		}
+		row = append(row,
+			strconv.Itoa(player.Points),
+			strconv.Itoa(player.CorrectCount),
+			strconv.Itoa(player.MaxStreak),
+			fmt.Sprintf("%d/%d", answered, session.QuestionCount),
+		)
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func exportAnswerRows(session *entity.GameSession) [][]string {
+	header := []string{"Player Name"}
+	for i := 0; i < session.QuestionCount; i++ {
+		header = append(header, exportQuestionTitle(session, i))
+	}
+	rows := [][]string{header}
+
+	for _, player := range session.Players {
+		row := make([]string, session.QuestionCount+1)
+		row[0] = escapeFormula(player.Name)
+		for i := 1; i < len(row); i++ {
+			row[i] = exportNoAnswer
+		}
+		for _, answer := range player.Answers {
+			if answer.QuestionIndex < 0 || answer.QuestionIndex >= session.QuestionCount {
+				continue
+			}
+			row[answer.QuestionIndex+1] = escapeFormula(exportAnswerCell(sessionQuestion(session, answer.QuestionIndex), answer))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func sessionQuestion(session *entity.GameSession, index int) *entity.GameSessionQuestion {
+	if index < 0 || index >= len(session.Questions) {
+		return nil
+	}
+	return &session.Questions[index]
+}
+
+func exportQuestionTitle(session *entity.GameSession, index int) string {
+	title := fmt.Sprintf("Q%d", index+1)
+	if question := sessionQuestion(session, index); question != nil && question.Name != "" {
+		title += ". " + question.Name
+	}
+	return title
+}
+
+func exportChoiceName(question *entity.GameSessionQuestion, index int) string {
+	if question != nil && index >= 0 && index < len(question.Choices) {
+		return question.Choices[index]
+	}
+	return fmt.Sprintf("#%d", index+1)
+}
+
+func exportAnswerCell(question *entity.GameSessionQuestion, answer entity.GameAnswer) string {
+	if !answer.Answered {
+		return exportNoAnswer
+	}
+
+	var value string
+	switch {
+	case len(answer.Order) > 0:
+		names := make([]string, 0, len(answer.Order))
+		for _, index := range answer.Order {
+			names = append(names, exportChoiceName(question, index))
+		}
+		value = strings.Join(names, " > ")
+	case answer.Value != nil:
+		value = strconv.FormatFloat(*answer.Value, 'f', -1, 64)
+	case answer.Text != "":
+		value = answer.Text
+	default:
+		names := make([]string, 0, len(answer.Choices))
+		for _, index := range answer.Choices {
+			names = append(names, exportChoiceName(question, index))
+		}
+		value = strings.Join(names, ", ")
+	}
+
+	if question != nil && (question.Type == entity.QuestionTypePoll || question.Type == entity.QuestionTypeWordCloud) {
+		return value
+	}
+
+	mark := "✗"
+	if answer.Correct {
+		mark = "✓"
+	} else if answer.Credit > 0 {
+		mark = fmt.Sprintf("%.0f%%", answer.Credit*100)
+	}
+	return fmt.Sprintf("%s (%s +%d)", value, mark, answer.Points)
+}
+
+func escapeFormula(value string) string {
+	if value == "" || value == exportNoAnswer {
+		return value
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value
+	}
+	switch value[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + value
+	}
+	return value
+}
+
+func writeExportCSV(rows [][]string) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("\ufeff")
+	writer := csv.NewWriter(&buf)
+
+	if err := writer.WriteAll(rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeExportCSVArchive(tables map[string][][]string) ([]byte, error) {
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, name := range names {
+		data, err := writeExportCSV(tables[name])
+		if err != nil {
+			return nil, err
+		}
+		file, err := archive.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := file.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeExportXLSX(summary [][]string, answers [][]string) ([]byte, error) {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	if err := file.SetSheetName("Sheet1", exportSummarySheet); err != nil {
+		return nil, err
+	}
+	if _, err := file.NewSheet(exportAnswersSheet); err != nil {
+		return nil, err
+	}
+
+	if err := writeExportSheet(file, exportSummarySheet, summary); err != nil {
+		return nil, err
+	}
+	if err := writeExportSheet(file, exportAnswersSheet, answers); err != nil {
+		return nil, err
+	}
+
+	buf, err := file.WriteToBuffer()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeExportSheet(file *excelize.File, sheet string, rows [][]string) error {
+	for i, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			return err
+		}
+		values := make([]interface{}, len(row))
+		for j, value := range row {
+			if number, err := strconv.Atoi(value); err == nil && i > 0 {
+				values[j] = number
+			} else {
+				values[j] = value
+			}
+		}
+		if err := file.SetSheetRow(sheet, cell, &values); err != nil {
+			return err
+		}
+	}
+	return file.SetPanes(sheet, &excelize.Panes{
+		Freeze:      true,
+		XSplit:      1,
+		YSplit:      1,
+		TopLeftCell: "B2",
+		ActivePane:  "bottomRight",
+	})
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"CorrectQuiz.com/quiz/internal/entity"
+)
+
+func exportTestSession() *entity.GameSession {
+	value := -3.5
+	return &entity.GameSession{
+		ID:            9,
+		Code:          "123456",
+		QuestionCount: 3,
+		Questions: []entity.GameSessionQuestion{
+			{Name: "เมืองหลวง", Type: entity.QuestionTypeSingle, Choices: []string{"เชียงใหม่", "กรุงเทพ"}},
+			{Name: "อุณหภูมิ", Type: entity.QuestionTypeNumeric},
+			{Name: "โหวต", Type: entity.QuestionTypePoll, Choices: []string{"ชอบ", "ไม่ชอบ"}},
+		},
+		Players: []entity.GamePlayerResult{
+			{
+				Name: "alice", Team: 1, Points: 200, CorrectCount: 2, MaxStreak: 2,
+				Answers: []entity.GameAnswer{
+					{QuestionIndex: 0, Answered: true, Choices: []int{1}, Correct: true, Credit: 1, Points: 100},
+					{QuestionIndex: 1, Answered: true, Value: &value, Correct: true, Credit: 1, Points: 100},
+					{QuestionIndex: 2, Answered: true, Choices: []int{0}},
+				},
+			},
+			{
+				Name: "=HYPERLINK(\"http://evil\")", Points: 200, CorrectCount: 1,
+				Answers: []entity.GameAnswer{
+					{QuestionIndex: 0, Answered: true, Choices: []int{1}, Correct: true, Credit: 1, Points: 200},
+				},
+			},
+			{Name: "bob", Team: 2, Points: 50},
+		},
+	}
+}
+
+func TestExportSummaryRows(t *testing.T) {
+	rows := exportSummaryRows(exportTestSession())
+
+	want := [][]string{
+		{"Rank", "Player Name", "Team", "Final Score", "Correct Answers", "Max Streak", "Answered"},
+		{"1", "alice", TeamName(1), "200", "2", "2", "3/3"},
+		{"1", "'=HYPERLINK(\"http://evil\")", "", "200", "1", "0", "1/3"},
+		{"3", "bob", TeamName(2), "50", "0", "0", "0/3"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d: %v", len(rows), len(want), rows)
+	}
+	for i := range want {
+		if !slices.Equal(rows[i], want[i]) {
+			t.Errorf("row %d = %q, want %q", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestExportSummaryRowsWithoutTeams(t *testing.T) {
+	session := exportTestSession()
+	for i := range session.Players {
+		session.Players[i].Team = 0
+	}
+
+	header := exportSummaryRows(session)[0]
+	if slices.Contains(header, "Team") {
+		t.Errorf("header %q has a Team column for a session without teams", header)
+	}
+}
+
+func TestExportAnswerRows(t *testing.T) {
+	rows := exportAnswerRows(exportTestSession())
+
+	want := [][]string{
+		{"Player Name", "Q1. เมืองหลวง", "Q2. อุณหภูมิ", "Q3. โหวต"},
+		{"alice", "กรุงเทพ (✓ +100)", "'-3.5 (✓ +100)", "ชอบ"},
+		{"'=HYPERLINK(\"http://evil\")", "กรุงเทพ (✓ +200)", "-", "-"},
+		{"bob", "-", "-", "-"},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("got %d rows, want %d: %v", len(rows), len(want), rows)
+	}
+	for i := range want {
+		if !slices.Equal(rows[i], want[i]) {
+			t.Errorf("row %d = %q, want %q", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestExportAnswerCell(t *testing.T) {
+	value := 42.5
+	single := &entity.GameSessionQuestion{Type: entity.QuestionTypeSingle, Choices: []string{"A", "B", "C"}}
+	multiple := &entity.GameSessionQuestion{Type: entity.QuestionTypeMultiple, Choices: []string{"A", "B", "C"}}
+	ordering := &entity.GameSessionQuestion{Type: entity.QuestionTypeOrdering, Choices: []string{"A", "B", "C"}}
+	text := &entity.GameSessionQuestion{Type: entity.QuestionTypeText}
+	wordCloud := &entity.GameSessionQuestion{Type: entity.QuestionTypeWordCloud}
+
+	tests := []struct {
+		name     string
+		question *entity.GameSessionQuestion
+		answer   entity.GameAnswer
+		want     string
+	}{
+		{"unanswered", single, entity.GameAnswer{}, "-"},
+		{"single correct", single, entity.GameAnswer{Answered: true, Choices: []int{1}, Correct: true, Credit: 1, Points: 120}, "B (✓ +120)"},
+		{"single wrong", single, entity.GameAnswer{Answered: true, Choices: []int{0}}, "A (✗ +0)"},
+		{"multiple partial", multiple, entity.GameAnswer{Answered: true, Choices: []int{0, 2}, Credit: 0.5, Points: 50}, "A, C (50% +50)"},
+		{"ordering", ordering, entity.GameAnswer{Answered: true, Order: []int{2, 0, 1}}, "C > A > B (✗ +0)"},
+		{"numeric", nil, entity.GameAnswer{Answered: true, Value: &value, Correct: true, Credit: 1, Points: 100}, "42.5 (✓ +100)"},
+		{"text", text, entity.GameAnswer{Answered: true, Text: "กรุงเทพ", Correct: true, Credit: 1, Points: 100}, "กรุงเทพ (✓ +100)"},
+		{"word cloud has no grade", wordCloud, entity.GameAnswer{Answered: true, Text: "สนุก"}, "สนุก"},
+		{"choice outside the snapshot", single, entity.GameAnswer{Answered: true, Choices: []int{5}}, "#6 (✗ +0)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exportAnswerCell(tt.question, tt.answer); got != tt.want {
+				t.Errorf("exportAnswerCell = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEscapeFormula(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"alice", "alice"},
+		{"=1+1", "'=1+1"},
+		{"+SUM(A1)", "'+SUM(A1)"},
+		{"-2+3", "'-2+3"},
+		{"@cmd", "'@cmd"},
+		{"\tname", "'\tname"},
+		{"\rname", "'\rname"},
+		{"-", "-"},
+		{"-3.5", "-3.5"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := escapeFormula(tt.value); got != tt.want {
+			t.Errorf("escapeFormula(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestCSVExportIsOneTablePerFile(t *testing.T) {
+	service := NewExportService(nil)
+	file, err := service.export(exportTestSession(), ExportFormatCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(file.Name, ".zip") || file.ContentType != "application/zip" {
+		t.Errorf("got %s (%s), want a zip archive", file.Name, file.ContentType)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(file.Data), int64(len(file.Data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(archive.File) != 2 {
+		t.Fatalf("archive has %d files, want summary and answers", len(archive.File))
+	}
+	for _, entry := range archive.File {
+		reader, err := entry.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		records, err := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff")))).ReadAll()
+		if err != nil {
+			t.Fatalf("%s: %v", entry.Name, err)
+		}
+		if len(records) != 4 {
+			t.Errorf("%s has %d records, want a header and 3 players", entry.Name, len(records))
+		}
+	}
+}
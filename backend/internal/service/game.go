@@ -5,10 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"math/rand"
+	"math"
 	"sort"
-	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"CorrectQuiz.com/quiz/internal/entity"
@@ -26,9 +26,26 @@ type Player struct {
 	CorrectStreak       int             `json:"correctStreak"`
 	AnswerTimeRemaining int             `json:"-"`
 	MaxCorrectStreak    int             `json:"-"`
-	CurrentAnswer       int             `json:"-"`
+	CurrentAnswer       PlayerAnswer    `json:"-"`
+	Team                int             `json:"team,omitempty"`
+	Disconnected        bool            `json:"-"`
+	disconnectedAt      time.Time
+	answeredAt          time.Time
+	questionIndex       int
+	questionStartedAt   time.Time
+	questionTimer       *time.Timer
+	awaitingNext        bool
+	finished            bool
+	choiceOrders        map[int][]int
+	answeredAny         bool
+	leftAfterStart      bool
 }
 
+const (
+	PlayerReconnectGrace = 30 * time.Second
+	HostReconnectGrace   = 2 * time.Minute
+)
+
 type GameState int
 
 const (
@@ -41,9 +58,11 @@ const (
 )
 
 type LeaderboardEntry struct {
-	Name         string `json:"name"`
-	Points       int    `json:"points"`
-	CorrectCount int    `json:"correctCount"`
+	PlayerId     uuid.UUID `json:"-"`
+	Name         string    `json:"name"`
+	Points       int       `json:"points"`
+	CorrectCount int       `json:"correctCount"`
+	Team         int       `json:"team,omitempty"`
 }
 
 type Game struct {
@@ -52,21 +71,78 @@ type Game struct {
 	Code                string
 	State               GameState
 	Time                int
+	clockMutex          sync.Mutex
 	Players             []*Player
+	departedPlayers     []*Player
 	playersMutex        sync.RWMutex
+	Teams               *TeamOptions
+	AutoPilot           *AutoPilotOptions
 	CurrentQuestion     int
 	Host                *websocket.Conn
+	HostUserID          uint
+	hostAway            bool
+	paused              bool
+	hostAwaySince       time.Time
+	hostReconnectGrace  time.Duration
 	netService          *NetService
 	correctAnswerCounts map[uuid.UUID]int
 	ctx                 context.Context
 	cancelFunc          context.CancelFunc
+	questionStartedAt   time.Time
+	session             *entity.GameSession
+	sessionResults      map[uuid.UUID]*entity.GamePlayerResult
+	sessionMutex        sync.Mutex
+	sessionWrites       []func()
+	sessionSignal       chan struct{}
+	sessionClosed       bool
+	Assignment          *AssignmentOptions
+	closeTimer          *time.Timer
+	selfPacedCorrect    map[int]int
+	lastActivity        atomic.Int64
 }
 
 type PlayerAnswerFeedbackPacket struct {
-	IsCorrect          bool  `json:"isCorrect"`
-	CorrectAnswerIndex []int `json:"correctAnswerIndex"`
-	StreakBonus        int   `json:"streakBonus"`
-	MaxStreak          int   `json:"maxStreak"`
+	IsCorrect          bool     `json:"isCorrect"`
+	Graded             bool     `json:"graded"`
+	Credit             float64  `json:"credit"`
+	CorrectAnswerIndex []int    `json:"correctAnswerIndex"`
+	AcceptedAnswers    []string `json:"acceptedAnswers,omitempty"`
+	CorrectValue       *float64 `json:"correctValue,omitempty"`
+	StreakBonus        int      `json:"streakBonus"`
+	MaxStreak          int      `json:"maxStreak"`
+}
+
+type ResumeTokenPacket struct {
+	Token string `json:"token"`
+}
+
+type GamePausePacket struct {
+	Paused bool `json:"paused"`
+}
+
+type GameSnapshotPacket struct {
+	Code          string             `json:"code"`
+	State         GameState          `json:"state"`
+	Players       []Player           `json:"players"`
+	QuestionIndex int                `json:"questionIndex"`
+	Question      *HostQuestion      `json:"question,omitempty"`
+	Tick          int                `json:"tick"`
+	Leaderboard   []LeaderboardEntry `json:"leaderboard"`
+	Teams         []TeamStanding     `json:"teams,omitempty"`
+	Paused        bool               `json:"paused"`
+}
+
+type PollResultsPacket struct {
+	QuestionIndex int       `json:"questionIndex"`
+	Counts        []int     `json:"counts"`
+	Percentages   []float64 `json:"percentages"`
+	Responses     int       `json:"responses"`
+}
+
+type WordCloudPacket struct {
+	QuestionIndex int               `json:"questionIndex"`
+	Words         []TextAnswerCount `json:"words"`
+	Responses     int               `json:"responses"`
 }
 
 type AnswerReceivedPacket struct {
@@ -74,20 +150,32 @@ type AnswerReceivedPacket struct {
 	ChoiceIndex int       `json:"choice_index"`
 }
 
-func generateCode() string {
-	return strconv.Itoa(100000 + rand.Intn(900000))
-}
-
 func (g *Game) AddPlayer(player *Player) {
+	g.touch()
 	g.playersMutex.Lock()
 	defer g.playersMutex.Unlock()
+	g.autoAssignTeamLocked(player)
 	g.Players = append(g.Players, player)
 }
 
 func (g *Game) RemovePlayer(playerId uuid.UUID) {
 	g.playersMutex.Lock()
 	defer g.playersMutex.Unlock()
+	g.removePlayerLocked(playerId)
+}
 
+func (g *Game) GetPlayer(playerId uuid.UUID) *Player {
+	g.playersMutex.RLock()
+	defer g.playersMutex.RUnlock()
+	for _, player := range g.Players {
+		if player.Id == playerId {
+			return player
+		}
+	}
+	return nil
+}
+
+func (g *Game) removePlayerLocked(playerId uuid.UUID) {
 	var removedPlayerName string
 	var newPlayers []*Player
 	for _, p := range g.Players {
@@ -95,6 +183,8 @@ func (g *Game) RemovePlayer(playerId uuid.UUID) {
 			newPlayers = append(newPlayers, p)
 		} else {
 			removedPlayerName = p.Name
+			p.leftAfterStart = g.State != LobbyState && !g.IsSelfPaced()
+			g.departedPlayers = append(g.departedPlayers, p)
 		}
 	}
 	if len(newPlayers) < len(g.Players) {
@@ -108,11 +198,11 @@ func newGame(quiz entity.Quiz, host *websocket.Conn, netService *NetService) Gam
 	return Game{
 		Id:                  uuid.New(),
 		Quiz:                quiz,
-		Code:                generateCode(),
 		Players:             []*Player{},
 		State:               LobbyState,
 		Time:                60,
 		Host:                host,
+		hostReconnectGrace:  HostReconnectGrace,
 		netService:          netService,
 		correctAnswerCounts: make(map[uuid.UUID]int),
 		ctx:                 ctx,
@@ -120,7 +210,14 @@ func newGame(quiz entity.Quiz, host *websocket.Conn, netService *NetService) Gam
 	}
 }
 
+func (g *Game) ResultPlayers() []*Player {
+	g.playersMutex.RLock()
+	defer g.playersMutex.RUnlock()
+	return append(append([]*Player{}, g.Players...), g.departedPlayers...)
+}
+
 func (g *Game) Start() {
+	g.balanceUnassignedTeams()
 	g.ChangeState(PlayState)
 	g.CurrentQuestion = 0
 
@@ -129,14 +226,12 @@ func (g *Game) Start() {
 		return
 	}
 
+	g.shuffleQuestions()
 	g.Time = g.Quiz.Questions[0].Time
+	g.questionStartedAt = time.Now()
+	g.startSession()
 
-	questionPacket := QuestionShowPacket{
-		Question:      g.Quiz.Questions[0],
-		QuestionIndex: g.CurrentQuestion,
-	}
-
-	g.BroadcastPacket(questionPacket, true)
+	g.sendQuestionShow(g.CurrentQuestion)
 
 	go func(gameCtx context.Context) {
 		ticker := time.NewTicker(time.Second)
@@ -167,11 +262,39 @@ func (g *Game) NextQuestion() {
 	g.ChangeState(PlayState)
 
 	g.Time = g.Quiz.Questions[g.CurrentQuestion].Time
+	g.questionStartedAt = time.Now()
 
-	g.BroadcastPacket(QuestionShowPacket{
-		Question:      g.Quiz.Questions[g.CurrentQuestion],
-		QuestionIndex: g.CurrentQuestion,
-	}, true)
+	g.sendQuestionShow(g.CurrentQuestion)
+}
+
+func (g *Game) isBonusRound(questionIndex int) bool {
+	return g.Quiz.BonusRoundMultiplier > 0 && questionIndex == len(g.Quiz.Questions)-1
+}
+
+func (g *Game) pointsMultiplier(questionIndex int) float64 {
+	multiplier := g.Quiz.Questions[questionIndex].Multiplier()
+	if g.isBonusRound(questionIndex) {
+		multiplier *= g.Quiz.BonusRoundMultiplier
+	}
+	return multiplier
+}
+
+func (g *Game) questionShowPacket(questionIndex int) QuestionShowPacket {
+	return QuestionShowPacket{
+		Question:         newPlayerQuestion(g.Quiz.Questions[questionIndex]),
+		QuestionIndex:    questionIndex,
+		PointsMultiplier: g.pointsMultiplier(questionIndex),
+		BonusRound:       g.isBonusRound(questionIndex),
+	}
+}
+
+func (g *Game) hostQuestionShowPacket(questionIndex int) HostQuestionShowPacket {
+	return HostQuestionShowPacket{
+		Question:         newHostQuestion(g.Quiz.Questions[questionIndex]),
+		QuestionIndex:    questionIndex,
+		PointsMultiplier: g.pointsMultiplier(questionIndex),
+		BonusRound:       g.isBonusRound(questionIndex),
+	}
 }
 
 func (g *Game) Reveal() {
@@ -202,6 +325,13 @@ func (g *Game) Reveal() {
 }
 
 func (g *Game) Tick() {
+	g.clockMutex.Lock()
+	defer g.clockMutex.Unlock()
+
+	if g.isPaused() {
+		return
+	}
+
 	if g.Time > 0 {
 		g.Time--
 		g.netService.SendPacket(g.Host, TickPacket{
@@ -221,6 +351,14 @@ func (g *Game) Tick() {
 
 				g.sendHostReveal(g.CurrentQuestion)
 				g.sendPlayerResults()
+
+				if g.AutoPilot != nil {
+					g.Time = g.AutoPilot.RevealSeconds
+				}
+			}
+		default:
+			if g.AutoPilot != nil {
+				g.autoPilotStep()
 			}
 		}
 	}
@@ -237,10 +375,14 @@ func (g *Game) GetCorrectAnswerCount(playerID uuid.UUID) (int, bool) {
 func (g *Game) Intermission() {
 	if g.CurrentQuestion >= len(g.Quiz.Questions)-1 {
 		g.ChangeState(EndState)
+		g.endSession()
 
 		leaderboardData := g.getLeaderboard()
+		standings := g.teamStandings()
+		ranksByTeam := teamRanks(standings)
 		leaderboardPacket := LeaderboardPacket{
 			Points: leaderboardData,
+			Teams:  standings,
 		}
 
 		g.playersMutex.RLock()
@@ -254,7 +396,11 @@ func (g *Game) Intermission() {
 		for i, entry := range leaderboardData {
 			for _, player := range g.Players {
 				if player.Name == entry.Name {
-					rankPacket := PlayerRankPacket{Rank: i + 1}
+					rankPacket := PlayerRankPacket{
+						Rank:     i + 1,
+						Team:     player.Team,
+						TeamRank: ranksByTeam[player.Team],
+					}
 					g.netService.SendPacket(player.Connection, rankPacket)
 					break
 				}
@@ -271,11 +417,12 @@ func (g *Game) Intermission() {
 		g.netService.SendPacket(hostConnection, leaderboardPacket)
 
 	} else {
+		g.touch()
 		g.State = IntermissionState
 
 		hostStatePacket := ChangeGameStatePacket{State: IntermissionState}
 		leaderboardData := g.getLeaderboard()
-		leaderboardPacket := LeaderboardPacket{Points: leaderboardData}
+		leaderboardPacket := LeaderboardPacket{Points: leaderboardData, Teams: g.teamStandings()}
 
 		g.netService.SendPacket(g.Host, hostStatePacket)
 		g.netService.SendPacket(g.Host, leaderboardPacket)
@@ -299,9 +446,11 @@ func (g *Game) getLeaderboard() []LeaderboardEntry {
 	leaderboard := []LeaderboardEntry{}
 	for _, player := range g.Players {
 		leaderboard = append(leaderboard, LeaderboardEntry{
+			PlayerId:     player.Id,
 			Name:         player.Name,
 			Points:       player.Points,
 			CorrectCount: g.correctAnswerCounts[player.Id],
+			Team:         player.Team,
 		})
 	}
 
@@ -309,6 +458,7 @@ func (g *Game) getLeaderboard() []LeaderboardEntry {
 }
 
 func (g *Game) ChangeState(state GameState) {
+	g.touch()
 	g.State = state
 	g.BroadcastPacket(ChangeGameStatePacket{
 		State: state,
@@ -325,22 +475,16 @@ func (g *Game) BroadcastPacket(packet any, includeHost bool) error {
 		}
 	}
 
-	g.playersMutex.RUnlock()
-
-	for _, conn := range connections {
-		g.netService.SendPacket(conn, packet)
-	}
-
 	if includeHost && g.Host != nil {
-		err := g.netService.SendPacket(g.Host, packet)
-		if err != nil {
-			return err
-		}
+		connections = append(connections, g.Host)
 	}
+	g.playersMutex.RUnlock()
+
+	g.netService.SendPacketToAll(connections, packet)
 	return nil
 }
 
-func (g *Game) OnPlayerJoin(name string, connection *websocket.Conn) {
+func (g *Game) OnPlayerJoin(name string, connection *websocket.Conn) *Player {
 	log.Printf("👤 OnPlayerJoin called for: %s (Game: %s)", name, g.Code)
 	player := Player{
 		Id:            uuid.New(),
@@ -364,7 +508,98 @@ func (g *Game) OnPlayerJoin(name string, connection *websocket.Conn) {
 	g.netService.SendPacket(connection, PlayerJoinPacket{
 		Player: player,
 	})
+	g.sendResumeToken(&player)
 	log.Println("✅ Player Joined Successfully!")
+	return &player
+}
+
+func (g *Game) sendResumeToken(player *Player) {
+	token, err := signResumeToken(g.Id, player.Id)
+	if err != nil {
+		log.Printf("Game %s: failed to sign resume token for %s: %v", g.Code, player.Name, err)
+		return
+	}
+	g.netService.SendPacket(player.Connection, ResumeTokenPacket{
+		Token: token,
+	})
+}
+
+func (g *Game) OnPlayerDisconnect(player *Player) {
+	g.playersMutex.Lock()
+	player.Connection = nil
+	player.Disconnected = true
+	player.disconnectedAt = time.Now()
+	disconnectedAt := player.disconnectedAt
+	g.playersMutex.Unlock()
+
+	grace := g.playerReconnectGrace()
+	log.Printf("Game %s: Player %s disconnected, holding seat for %s", g.Code, player.Name, grace)
+
+	time.AfterFunc(grace, func() {
+		g.expireDisconnectedPlayer(player.Id, disconnectedAt)
+	})
+}
+
+func (g *Game) expireDisconnectedPlayer(playerId uuid.UUID, disconnectedAt time.Time) {
+	g.playersMutex.Lock()
+	expired := false
+	for _, p := range g.Players {
+		if p.Id == playerId && p.Disconnected && p.disconnectedAt.Equal(disconnectedAt) {
+			expired = true
+			break
+		}
+	}
+	if expired {
+		g.removePlayerLocked(playerId)
+	}
+	hostConnection := g.Host
+	g.playersMutex.Unlock()
+
+	if !expired {
+		return
+	}
+
+	g.netService.SendPacket(hostConnection, PlayerLeavePacket{
+		PlayerId: playerId,
+	})
+}
+
+func (g *Game) OnPlayerResume(player *Player, connection *websocket.Conn) {
+	g.touch()
+	g.playersMutex.Lock()
+	oldConnection := player.Connection
+	player.Connection = connection
+	player.Disconnected = false
+	g.playersMutex.Unlock()
+
+	if oldConnection != nil && oldConnection != connection {
+		g.netService.CloseConnection(oldConnection)
+	}
+
+	log.Printf("Game %s: Player %s (ID: %s) resumed", g.Code, player.Name, player.Id)
+
+	g.netService.SendPacket(connection, ChangeGameStatePacket{
+		State: g.State,
+	})
+	g.netService.SendPacket(connection, PlayerJoinPacket{
+		Player: *player,
+	})
+	g.sendResumeToken(player)
+	g.netService.SendPacket(connection, PlayerRevealPacket{
+		Points: player.Points,
+	})
+
+	if g.IsSelfPaced() {
+		g.resumeSelfPaced(player)
+		return
+	}
+
+	if g.State == PlayState && g.CurrentQuestion >= 0 && g.CurrentQuestion < len(g.Quiz.Questions) {
+		g.netService.SendPacket(connection, g.playerQuestionShowPacket(player, g.CurrentQuestion))
+		g.netService.SendPacket(connection, TickPacket{
+			Tick: g.Time,
+		})
+	}
 }
 
 func (g *Game) KickPlayer(playerID string) error {
@@ -372,12 +607,10 @@ func (g *Game) KickPlayer(playerID string) error {
 	defer g.playersMutex.Unlock()
 
 	var playerToKick *Player = nil
-	var playerIndex = -1
 
-	for i, player := range g.Players {
+	for _, player := range g.Players {
 		if player.Id.String() == playerID {
 			playerToKick = player
-			playerIndex = i
 			break
 		}
 	}
@@ -387,61 +620,196 @@ func (g *Game) KickPlayer(playerID string) error {
 	}
 
 	if playerToKick.Connection != nil {
-		playerToKick.Connection.WriteMessage(websocket.CloseMessage, []byte{})
+		g.netService.CloseConnection(playerToKick.Connection)
 	}
 
-	g.Players = append(g.Players[:playerIndex], g.Players[playerIndex+1:]...)
+	g.removePlayerLocked(playerToKick.Id)
 
 	return nil
 }
 
+func (g *Game) OnHostDisconnect() {
+	g.playersMutex.Lock()
+	g.Host = nil
+	g.hostAway = true
+	g.hostAwaySince = time.Now()
+	awaySince := g.hostAwaySince
+	g.playersMutex.Unlock()
+
+	log.Printf("Game %s: Host disconnected, pausing for %s", g.Code, g.hostReconnectGrace)
+	g.BroadcastPacket(GamePausePacket{Paused: true}, false)
+
+	time.AfterFunc(g.hostReconnectGrace, func() {
+		g.playersMutex.RLock()
+		expired := g.hostAway && g.hostAwaySince.Equal(awaySince)
+		g.playersMutex.RUnlock()
+
+		if expired {
+			log.Printf("Game %s: Host did not return, ending game", g.Code)
+			g.netService.endGame(g)
+		}
+	})
+}
+
+func (g *Game) OnHostResume(connection *websocket.Conn) {
+	g.touch()
+	g.playersMutex.Lock()
+	oldConnection := g.Host
+	g.Host = connection
+	wasAway := g.hostAway
+	g.hostAway = false
+	g.playersMutex.Unlock()
+
+	if oldConnection != nil && oldConnection != connection {
+		g.netService.CloseConnection(oldConnection)
+	}
+
+	log.Printf("Game %s: Host resumed", g.Code)
+
+	g.netService.SendPacket(connection, HostGamePacket{
+		QuizId: g.Code,
+	})
+	g.sendHostResumeToken()
+	g.netService.SendPacket(connection, g.snapshot())
+
+	g.playersMutex.RLock()
+	paused := g.paused
+	g.playersMutex.RUnlock()
+
+	if wasAway && !paused {
+		g.BroadcastPacket(GamePausePacket{Paused: false}, false)
+	}
+}
+
+func (g *Game) sendHostResumeToken() {
+	token, err := signHostResumeToken(g.Id)
+	if err != nil {
+		log.Printf("Game %s: failed to sign host resume token: %v", g.Code, err)
+		return
+	}
+	g.netService.SendPacket(g.Host, ResumeTokenPacket{
+		Token: token,
+	})
+}
+
+func (g *Game) snapshot() GameSnapshotPacket {
+	leaderboard := g.getLeaderboard()
+	teams := g.teamStandings()
+
+	g.playersMutex.RLock()
+	defer g.playersMutex.RUnlock()
+
+	players := make([]Player, 0, len(g.Players))
+	for _, player := range g.Players {
+		players = append(players, *player)
+	}
+
+	packet := GameSnapshotPacket{
+		Code:          g.Code,
+		State:         g.State,
+		Players:       players,
+		QuestionIndex: g.CurrentQuestion,
+		Tick:          g.Time,
+		Leaderboard:   leaderboard,
+		Teams:         teams,
+		Paused:        g.paused,
+	}
+	if g.State != LobbyState && g.CurrentQuestion >= 0 && g.CurrentQuestion < len(g.Quiz.Questions) {
+		question := newHostQuestion(g.Quiz.Questions[g.CurrentQuestion])
+		packet.Question = &question
+	}
+	return packet
+}
+
 func (g *Game) sendHostReveal(questionIndex int) {
 	if questionIndex < 0 || questionIndex >= len(g.Quiz.Questions) {
 		return
 	}
 
 	currentQuestion := g.Quiz.Questions[questionIndex]
-	var correctAnswerIndex []int
-	for i, choice := range currentQuestion.Choices {
-		if choice.Correct {
-			correctAnswerIndex = append(correctAnswerIndex, i)
-		}
-	}
+	correctAnswerIndex := correctChoiceIndexes(currentQuestion)
 
 	counts := make([]int, len(currentQuestion.Choices))
+	textAnswers := []string{}
+	numericAnswers := []float64{}
+	placementCounts := make([]int, len(currentQuestion.Choices))
 
 	g.playersMutex.RLock()
 	defer g.playersMutex.RUnlock()
 
 	for _, player := range g.Players {
 		if player.Answered {
-			playerChoiceIndex := player.CurrentAnswer
-			if playerChoiceIndex >= 0 && playerChoiceIndex < len(counts) {
-				counts[playerChoiceIndex]++
+			if currentQuestion.Type == entity.QuestionTypeText || currentQuestion.Type == entity.QuestionTypeWordCloud {
+				textAnswers = append(textAnswers, player.CurrentAnswer.Text)
+				continue
+			}
+			if currentQuestion.Type == entity.QuestionTypeOrdering {
+				if isChoicePermutation(currentQuestion, player.CurrentAnswer.Order) {
+					for position, choiceIndex := range player.CurrentAnswer.Order {
+						if correctAnswerIndex[position] == choiceIndex {
+							placementCounts[choiceIndex]++
+						}
+					}
+				}
+				continue
+			}
+			if currentQuestion.Type == entity.QuestionTypeNumeric {
+				if player.CurrentAnswer.Value != nil {
+					if value, ok := snapNumericAnswer(currentQuestion.Numeric, *player.CurrentAnswer.Value); ok {
+						numericAnswers = append(numericAnswers, value)
+					}
+				}
+				continue
+			}
+			for _, choiceIndex := range selectedChoiceIndexes(currentQuestion, player.CurrentAnswer) {
+				counts[choiceIndex]++
 			}
 		}
 	}
 
 	packet := QuestionRevealPacket{
-		Question:           currentQuestion,
+		Question:           newHostQuestion(currentQuestion),
 		CorrectAnswerIndex: correctAnswerIndex,
 		AnswerCounts:       counts,
 	}
+	if currentQuestion.Type == entity.QuestionTypePoll {
+		packet.CorrectAnswerIndex = nil
+		_, packet.Percentages = votePercentages(counts)
+	}
+	if currentQuestion.Type == entity.QuestionTypeText || currentQuestion.Type == entity.QuestionTypeWordCloud {
+		packet.TextAnswers = textAnswerFrequencies(currentQuestion, textAnswers)
+	}
+	if currentQuestion.Type == entity.QuestionTypeOrdering {
+		packet.CorrectPlacementCounts = placementCounts
+	}
+	if currentQuestion.Type == entity.QuestionTypeNumeric {
+		packet.Histogram = numericHistogram(currentQuestion.Numeric, numericAnswers)
+	}
 	g.netService.SendPacket(g.Host, packet)
 }
 
-func (g *Game) OnPlayerAnswer(questionIndex int, choiceIndex int, player *Player) {
+func (g *Game) OnPlayerAnswer(questionIndex int, answer PlayerAnswer, player *Player) {
+	if g.IsSelfPaced() {
+		g.onSelfPacedAnswer(questionIndex, answer, player)
+		return
+	}
+	g.touch()
+
+	g.clockMutex.Lock()
+	timeRemaining := g.Time
+	g.clockMutex.Unlock()
 
 	g.playersMutex.Lock()
 
 	if player.Answered {
-		g.playersMutex.Lock()
+		g.playersMutex.Unlock()
 		return
 	}
 
 	player.Answered = true
-	player.CurrentAnswer = choiceIndex
-	player.AnswerTimeRemaining = g.Time
+	player.CurrentAnswer = g.canonicalAnswerLocked(player, g.CurrentQuestion, answer)
+	player.AnswerTimeRemaining = timeRemaining
+	player.answeredAt = time.Now()
 
 	allAnswered := true
 	if len(g.Players) == 0 {
@@ -455,20 +823,66 @@ func (g *Game) OnPlayerAnswer(questionIndex int, choiceIndex int, player *Player
 		}
 	}
 
-	if allAnswered && g.State == PlayState {
-		g.Time = 0
+	g.playersMutex.Unlock()
+
+	if allAnswered {
+		g.clockMutex.Lock()
+		if g.State == PlayState {
+			g.Time = 0
+		}
+		g.clockMutex.Unlock()
 	}
 
-	g.playersMutex.Unlock()
+	if questionIndex >= 0 && questionIndex < len(g.Quiz.Questions) && !g.Quiz.Questions[questionIndex].IsGraded() {
+		g.sendLiveResults(questionIndex)
+	}
+}
 
-	if questionIndex < 0 || questionIndex >= len(g.Quiz.Questions) {
-		return
+func (g *Game) sendLiveResults(questionIndex int) {
+	question := g.Quiz.Questions[questionIndex]
+
+	counts := make([]int, len(question.Choices))
+	texts := []string{}
+
+	g.playersMutex.RLock()
+	for _, player := range g.Players {
+		if !player.Answered {
+			continue
+		}
+		if question.Type == entity.QuestionTypeWordCloud {
+			texts = append(texts, player.CurrentAnswer.Text)
+			continue
+		}
+		for _, choiceIndex := range selectedChoiceIndexes(question, player.CurrentAnswer) {
+			counts[choiceIndex]++
+		}
 	}
+	hostConnection := g.Host
+	g.playersMutex.RUnlock()
 
-	currentQuestion := g.Quiz.Questions[questionIndex]
-	if choiceIndex < 0 || choiceIndex >= len(currentQuestion.Choices) {
+	if question.Type == entity.QuestionTypeWordCloud {
+		g.netService.SendPacket(hostConnection, WordCloudPacket{
+			QuestionIndex: questionIndex,
+			Words:         textAnswerFrequencies(question, texts),
+			Responses:     len(texts),
+		})
 		return
 	}
+
+	responses, percentages := votePercentages(counts)
+	g.netService.SendPacket(hostConnection, PollResultsPacket{
+		QuestionIndex: questionIndex,
+		Counts:        counts,
+		Percentages:   percentages,
+		Responses:     responses,
+	})
+}
+
+func questionTimeLimit(question entity.QuizQuestion) int {
+	if question.Time <= 0 {
+		return 60
+	}
+	return question.Time
 }
 
 func (g *Game) sendPlayerResults() {
@@ -477,19 +891,7 @@ func (g *Game) sendPlayerResults() {
 	}
 
 	currentQuestion := g.Quiz.Questions[g.CurrentQuestion]
-	var correctAnswerIndex []int
-	for i, choice := range currentQuestion.Choices {
-		if choice.Correct {
-			correctAnswerIndex = append(correctAnswerIndex, i)
-		}
-	}
-
-	totalTime := float64(currentQuestion.Time)
-	if totalTime <= 0 {
-		totalTime = 60.0
-	}
-
-	const basePointsForCorrect = 100.0
+	graded := currentQuestion.IsGraded()
 
 	type PlayerPacketPair struct {
 		Connection *websocket.Conn
@@ -497,18 +899,15 @@ func (g *Game) sendPlayerResults() {
 		Reveal     PlayerRevealPacket
 	}
 	packetsToSend := []PlayerPacketPair{}
+	records := []answerRecord{}
 	correctPlayers := []*Player{}
+	credits := make(map[uuid.UUID]float64)
 
 	for _, player := range g.Players {
-		if player.Answered {
-			isCorrect := false
-			for _, correctIdx := range correctAnswerIndex {
-				if player.CurrentAnswer == correctIdx {
-					isCorrect = true
-					break
-				}
-			}
-			if isCorrect {
+		if player.Answered && graded {
+			credit := gradeAnswer(currentQuestion, player.CurrentAnswer)
+			credits[player.Id] = credit
+			if credit >= 1 {
 				correctPlayers = append(correctPlayers, player)
 			}
 		}
@@ -518,70 +917,21 @@ func (g *Game) sendPlayerResults() {
 		return correctPlayers[i].AnswerTimeRemaining > correctPlayers[j].AnswerTimeRemaining
 	})
 
-	pointsMap := make(map[uuid.UUID]int)
-	rankedScores := []int{26, 24, 22}
-	baseCorrectScore := 20
-
+	ranks := make(map[uuid.UUID]int)
 	for i, player := range correctPlayers {
-		score := baseCorrectScore
-		if i < len(rankedScores) {
-			score = rankedScores[i]
-		}
-		pointsMap[player.Id] = score
+		ranks[player.Id] = i
 	}
 
 	g.playersMutex.Lock()
 	for _, player := range g.Players {
-		isCorrect := false
-		if player.Answered {
-			for _, correctIdx := range correctAnswerIndex {
-				if player.CurrentAnswer == correctIdx {
-					isCorrect = true
-					break
-				}
-			}
+		rank, ok := ranks[player.Id]
+		if !ok {
+			rank = -1
 		}
 
-		var awardedPointsThisRound int = 0
-		var streakBonus int = 0
-
-		if isCorrect {
-			awardedPointsThisRound = int(basePointsForCorrect)
-			awardedPointsThisRound += pointsMap[player.Id]
-			g.correctAnswerCounts[player.Id]++
-
-			player.CorrectStreak++
-
-			if player.CorrectStreak > player.MaxCorrectStreak {
-				player.MaxCorrectStreak = player.CorrectStreak
-			}
-
-			if player.CorrectStreak == 2 {
-				streakBonus = 10
-				awardedPointsThisRound += streakBonus
-			} else if player.CorrectStreak == 3 {
-				streakBonus = 20
-				awardedPointsThisRound += streakBonus
-				player.CorrectStreak = 0
-			}
-
-			player.Points += awardedPointsThisRound
-
-		} else {
-			player.CorrectStreak = 0
-		}
-
-		player.LastAwardedPoints = awardedPointsThisRound
-
-		feedbackPacket := PlayerAnswerFeedbackPacket{
-			IsCorrect:          isCorrect,
-			CorrectAnswerIndex: correctAnswerIndex,
-			StreakBonus:        streakBonus,
-			MaxStreak:          player.MaxCorrectStreak,
-		}
-		revealPacket := PlayerRevealPacket{
-			Points: player.Points,
-		}
+		record, feedbackPacket, revealPacket := g.scorePlayerLocked(player, g.CurrentQuestion, credits[player.Id], rank)
+		record.startedAt = g.questionStartedAt
+		records = append(records, record)
 
 		if player.Connection != nil {
 			packetsToSend = append(packetsToSend, PlayerPacketPair{
@@ -600,4 +950,83 @@ func (g *Game) sendPlayerResults() {
 			g.netService.SendPacket(pair.Connection, pair.Reveal)
 		}
 	}
+
+	g.recordQuestion(g.CurrentQuestion, records)
+}
+
+func (g *Game) scorePlayerLocked(player *Player, questionIndex int, credit float64, rank int) (answerRecord, PlayerAnswerFeedbackPacket, PlayerRevealPacket) {
+	question := g.Quiz.Questions[questionIndex]
+	graded := question.IsGraded()
+	isCorrect := credit >= 1
+
+	var correctValue *float64
+	if question.Type == entity.QuestionTypeNumeric {
+		correctValue = &question.Numeric.Correct
+	}
+
+	var awardedPointsThisRound int = 0
+	var streakBonus int = 0
+
+	if isCorrect {
+		g.correctAnswerCounts[player.Id]++
+
+		player.CorrectStreak++
+
+		if player.CorrectStreak > player.MaxCorrectStreak {
+			player.MaxCorrectStreak = player.CorrectStreak
+		}
+	} else if graded {
+		player.CorrectStreak = 0
+	}
+
+	if player.Answered {
+		player.answeredAny = true
+	}
+
+	if graded && player.Answered {
+		multiplier := g.pointsMultiplier(questionIndex)
+		result := scoringStrategyFor(g.Quiz.ScoringStrategy).Score(ScoreInput{
+			Credit:        credit,
+			Rank:          rank,
+			TimeRemaining: player.AnswerTimeRemaining,
+			TimeLimit:     questionTimeLimit(question),
+			Streak:        player.CorrectStreak,
+		})
+
+		streakBonus = int(math.Round(float64(result.StreakBonus) * multiplier))
+		awardedPointsThisRound = int(math.Round(float64(result.Points)*multiplier)) + streakBonus
+		if result.ResetStreak {
+			player.CorrectStreak = 0
+		}
+
+		player.Points += awardedPointsThisRound
+	}
+
+	player.LastAwardedPoints = awardedPointsThisRound
+
+	record := answerRecord{
+		player:     player,
+		answer:     player.CurrentAnswer,
+		answered:   player.Answered,
+		answeredAt: player.answeredAt,
+		correct:    isCorrect,
+		credit:     credit,
+		points:     awardedPointsThisRound,
+	}
+
+	feedbackPacket := PlayerAnswerFeedbackPacket{
+		IsCorrect:          isCorrect,
+		Graded:             graded,
+		Credit:             credit,
+		CorrectAnswerIndex: g.playerViewIndexesLocked(player, questionIndex, correctChoiceIndexes(question)),
+		AcceptedAnswers:    question.AcceptedAnswers,
+		CorrectValue:       correctValue,
+		StreakBonus:        streakBonus,
+		MaxStreak:          player.MaxCorrectStreak,
+	}
+	revealPacket := PlayerRevealPacket{
+		Points: player.Points,
+	}
+
+	return record, feedbackPacket, revealPacket
 }
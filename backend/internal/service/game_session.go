@@ -0,0 +1,320 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"CorrectQuiz.com/quiz/internal/collection"
+	"CorrectQuiz.com/quiz/internal/entity"
+	"github.com/google/uuid"
+)
+
+type GameSessionService struct {
+	sessionCollection collection.GameSessionRepository
+}
+
+func NewGameSessionService(sessionRepo collection.GameSessionRepository) *GameSessionService {
+	return &GameSessionService{
+		sessionCollection: sessionRepo,
+	}
+}
+
+func (s *GameSessionService) StartSession(quiz entity.Quiz, code string) (*entity.GameSession, error) {
+	questions := make([]entity.GameSessionQuestion, 0, len(quiz.Questions))
+	for _, question := range quiz.Questions {
+		choices := make([]string, 0, len(question.Choices))
+		for _, choice := range question.Choices {
+			choices = append(choices, choice.Name)
+		}
+		questions = append(questions, entity.GameSessionQuestion{
+			ID:      question.ID,
+			Name:    question.Name,
+			Type:    question.Type,
+			Choices: choices,
+		})
+	}
+
+	session := &entity.GameSession{
+		Code:          code,
+		QuizID:        quiz.ID,
+		QuizName:      quiz.Name,
+		HostUserID:    quiz.UserID,
+		QuestionCount: len(quiz.Questions),
+		Questions:     questions,
+		StartedAt:     time.Now(),
+	}
+	if err := s.sessionCollection.CreateSession(session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+func (s *GameSessionService) EndSession(session *entity.GameSession) error {
+	endedAt := time.Now()
+	if err := s.sessionCollection.EndSession(session.ID, endedAt); err != nil {
+		return err
+	}
+	session.EndedAt = &endedAt
+	return nil
+}
+
+func (s *GameSessionService) SavePlayerResults(results []*entity.GamePlayerResult) error {
+	return s.sessionCollection.SavePlayerResults(results)
+}
+
+func (s *GameSessionService) RecordAnswers(answers []entity.GameAnswer) error {
+	return s.sessionCollection.CreateAnswers(answers)
+}
+
+func (s *GameSessionService) GetSessionsByHost(hostUserID uint) ([]entity.GameSession, error) {
+	return s.sessionCollection.GetSessionsByHost(hostUserID)
+}
+
+func (s *GameSessionService) GetSessionsByQuiz(quizID uint) ([]entity.GameSession, error) {
+	return s.sessionCollection.GetSessionsByQuiz(quizID)
+}
+
+func (s *GameSessionService) GetLatestSessionForHost(code string, hostUserID uint) (*entity.GameSession, error) {
+	return s.sessionCollection.GetLatestSessionByCode(code, hostUserID)
+}
+
+func (s *GameSessionService) GetSessionForHost(id uint, hostUserID uint) (*entity.GameSession, error) {
+	session, err := s.sessionCollection.GetSessionById(id)
+	if err != nil {
+		return nil, err
+	}
+	if session.HostUserID != uint64(hostUserID) {
+		return nil, errors.New("game session not found")
+	}
+	return session, nil
+}
+
+type answerRecord struct {
+	player     *Player
+	answer     PlayerAnswer
+	answered   bool
+	answeredAt time.Time
+	startedAt  time.Time
+	correct    bool
+	credit     float64
+	points     int
+}
+
+type playerResultSnapshot struct {
+	playerID     uuid.UUID
+	name         string
+	team         int
+	points       int
+	correctCount int
+	maxStreak    int
+}
+
+func (g *Game) startSession() {
+	sessionService := g.netService.sessionService
+	if sessionService == nil {
+		return
+	}
+
+	session, err := sessionService.StartSession(g.Quiz, g.Code)
+	if err != nil {
+		log.Printf("Game %s: failed to start game session record: %v", g.Code, err)
+		return
+	}
+	g.session = session
+	g.sessionResults = make(map[uuid.UUID]*entity.GamePlayerResult)
+
+	g.startSessionWriter()
+	g.persistPlayers(g.ResultPlayers())
+}
+
+// Writes are queued without a bound so a slow database never blocks the
+// game loop, and they run one at a time in the order they were queued.
+func (g *Game) startSessionWriter() {
+	g.sessionMutex.Lock()
+	g.sessionSignal = make(chan struct{}, 1)
+	g.sessionMutex.Unlock()
+
+	go g.runSessionWriter(g.sessionSignal)
+}
+
+func (g *Game) runSessionWriter(signal <-chan struct{}) {
+	for {
+		g.sessionMutex.Lock()
+		writes := g.sessionWrites
+		g.sessionWrites = nil
+		closed := g.sessionClosed
+		g.sessionMutex.Unlock()
+
+		for _, write := range writes {
+			write()
+		}
+		if len(writes) > 0 {
+			continue
+		}
+		if closed {
+			return
+		}
+		<-signal
+	}
+}
+
+func (g *Game) queueSessionWrite(write func()) {
+	g.sessionMutex.Lock()
+	if g.sessionSignal == nil || g.sessionClosed {
+		g.sessionMutex.Unlock()
+		return
+	}
+	g.sessionWrites = append(g.sessionWrites, write)
+	signal := g.sessionSignal
+	g.sessionMutex.Unlock()
+
+	notifySessionWriter(signal)
+}
+
+func notifySessionWriter(signal chan struct{}) {
+	select {
+	case signal <- struct{}{}:
+	default:
+	}
+}
+
+func (g *Game) snapshotPlayers(players []*Player) []playerResultSnapshot {
+	g.playersMutex.RLock()
+	defer g.playersMutex.RUnlock()
+
+	snapshots := make([]playerResultSnapshot, 0, len(players))
+	for _, player := range players {
+		snapshots = append(snapshots, playerResultSnapshot{
+			playerID:     player.Id,
+			name:         player.Name,
+			team:         player.Team,
+			points:       player.Points,
+			correctCount: g.correctAnswerCounts[player.Id],
+			maxStreak:    player.MaxCorrectStreak,
+		})
+	}
+	return snapshots
+}
+
+func (g *Game) persistPlayers(players []*Player) {
+	if g.session == nil {
+		return
+	}
+
+	snapshots := g.snapshotPlayers(players)
+	g.queueSessionWrite(func() {
+		g.savePlayerResults(snapshots)
+	})
+}
+
+func (g *Game) savePlayerResults(snapshots []playerResultSnapshot) {
+	results := make([]*entity.GamePlayerResult, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		result, ok := g.sessionResults[snapshot.playerID]
+		if !ok {
+			result = &entity.GamePlayerResult{
+				GameSessionID: g.session.ID,
+				PlayerID:      snapshot.playerID.String(),
+			}
+			g.sessionResults[snapshot.playerID] = result
+		}
+		result.Name = snapshot.name
+		result.Team = snapshot.team
+		result.Points = snapshot.points
+		result.CorrectCount = snapshot.correctCount
+		result.MaxStreak = snapshot.maxStreak
+		results = append(results, result)
+	}
+
+	if err := g.netService.sessionService.SavePlayerResults(results); err != nil {
+		log.Printf("Game %s: failed to save player results: %v", g.Code, err)
+	}
+}
+
+func (g *Game) recordQuestion(questionIndex int, records []answerRecord) {
+	if g.session == nil {
+		return
+	}
+
+	players := make([]*Player, 0, len(records))
+	for _, record := range records {
+		players = append(players, record.player)
+	}
+	snapshots := g.snapshotPlayers(players)
+
+	g.queueSessionWrite(func() {
+		g.savePlayerResults(snapshots)
+		g.saveAnswers(questionIndex, records)
+	})
+}
+
+func (g *Game) saveAnswers(questionIndex int, records []answerRecord) {
+	question := g.Quiz.Questions[questionIndex]
+	answers := make([]entity.GameAnswer, 0, len(records))
+	for _, record := range records {
+		result, ok := g.sessionResults[record.player.Id]
+		if !ok || result.ID == 0 {
+			continue
+		}
+
+		answer := entity.GameAnswer{
+			GameSessionID:      g.session.ID,
+			GamePlayerResultID: result.ID,
+			QuestionIndex:      questionIndex,
+			QuestionID:         question.ID,
+			Answered:           record.answered,
+			Correct:            record.correct,
+			Credit:             record.credit,
+			Points:             record.points,
+		}
+
+		if record.answered {
+			answeredAt := record.answeredAt
+			answer.AnsweredAt = &answeredAt
+			answer.ResponseTimeMs = record.answeredAt.Sub(record.startedAt).Milliseconds()
+			answer.Text = record.answer.Text
+			answer.Value = record.answer.Value
+			answer.Order = record.answer.Order
+			switch question.Type {
+			case entity.QuestionTypeText, entity.QuestionTypeNumeric, entity.QuestionTypeOrdering, entity.QuestionTypeWordCloud:
+			default:
+				answer.Choices = selectedChoiceIndexes(question, record.answer)
+				for _, choiceIndex := range answer.Choices {
+					answer.ChoiceIDs = append(answer.ChoiceIDs, question.Choices[choiceIndex].ID)
+				}
+			}
+		}
+
+		answers = append(answers, answer)
+	}
+
+	if err := g.netService.sessionService.RecordAnswers(answers); err != nil {
+		log.Printf("Game %s: failed to record answers for question %d: %v", g.Code, questionIndex, err)
+	}
+}
+
+func (g *Game) endSession() {
+	if g.session == nil {
+		return
+	}
+
+	snapshots := g.snapshotPlayers(g.ResultPlayers())
+
+	g.sessionMutex.Lock()
+	if g.sessionSignal == nil || g.sessionClosed {
+		g.sessionMutex.Unlock()
+		return
+	}
+	g.sessionClosed = true
+	g.sessionWrites = append(g.sessionWrites, func() {
+		g.savePlayerResults(snapshots)
+		if err := g.netService.sessionService.EndSession(g.session); err != nil {
+			log.Printf("Game %s: failed to end game session record: %v", g.Code, err)
+		}
+	})
+	signal := g.sessionSignal
+	g.sessionMutex.Unlock()
+
+	notifySessionWriter(signal)
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestQueueSessionWriteDoesNotBlockOnSlowWrites(t *testing.T) {
+	game := &Game{}
+	game.startSessionWriter()
+
+	release := make(chan struct{})
+	done := make(chan struct{})
+	var order []int
+
+	game.queueSessionWrite(func() { <-release })
+	queued := make(chan struct{})
+	go func() {
+		for i := 0; i < 1000; i++ {
+			game.queueSessionWrite(func() { order = append(order, i) })
+		}
+		game.sessionMutex.Lock()
+		game.sessionClosed = true
+		game.sessionWrites = append(game.sessionWrites, func() { close(done) })
+		game.sessionMutex.Unlock()
+		notifySessionWriter(game.sessionSignal)
+		close(queued)
+	}()
+
+	select {
+	case <-queued:
+	case <-time.After(time.Second):
+		t.Fatal("queueing writes blocked behind a slow write")
+	}
+
+	game.queueSessionWrite(func() { t.Error("write queued after the session closed was run") })
+	close(release)
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("queued writes were not run")
+	}
+	if len(order) != 1000 || !slices.IsSorted(order) {
+		t.Errorf("ran %d writes in order %v, want 1000 in queue order", len(order), order[:min(len(order), 10)])
+	}
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"math"
+	"sort"
+
+	"CorrectQuiz.com/quiz/internal/entity"
+)
+
+type PlayerAnswer struct {
+	Choice  int
+	Choices []int
+	Text    string
+	Value   *float64
+	Order   []int
+}
+
+type TextAnswerCount struct {
+	Text    string `json:"text"`
+	Count   int    `json:"count"`
+	Correct bool   `json:"correct"`
+}
+
+func correctChoiceIndexes(question entity.QuizQuestion) []int {
+	if question.Type == entity.QuestionTypeOrdering {
+		return correctChoiceOrder(question)
+	}
+
+	var correctAnswerIndex []int
+	for i, choice := range question.Choices {
+		if choice.Correct {
+			correctAnswerIndex = append(correctAnswerIndex, i)
+		}
+	}
+	return correctAnswerIndex
+}
+
+func textAnswerFrequencies(question entity.QuizQuestion, answers []string) []TextAnswerCount {
+	indexByKey := make(map[string]int)
+	frequencies := []TextAnswerCount{}
+	for _, text := range answers {
+		key := normalizeTextAnswer(text, question.TextMatch)
+		if key == "" {
+			continue
+		}
+		if i, ok := indexByKey[key]; ok {
+			frequencies[i].Count++
+			continue
+		}
+		indexByKey[key] = len(frequencies)
+		frequencies = append(frequencies, TextAnswerCount{
+			Text:    text,
+			Count:   1,
+			Correct: matchesAcceptedAnswer(question, text),
+		})
+	}
+
+	sort.SliceStable(frequencies, func(i, j int) bool {
+		return frequencies[i].Count > frequencies[j].Count
+	})
+	return frequencies
+}
+
+func correctChoiceOrder(question entity.QuizQuestion) []int {
+	order := make([]int, len(question.Choices))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return question.Choices[order[i]].Position < question.Choices[order[j]].Position
+	})
+	return order
+}
+
+func isChoicePermutation(question entity.QuizQuestion, order []int) bool {
+	if len(order) != len(question.Choices) {
+		return false
+	}
+	seen := make([]bool, len(order))
+	for _, idx := range order {
+		if idx < 0 || idx >= len(seen) || seen[idx] {
+			return false
+		}
+		seen[idx] = true
+	}
+	return true
+}
+
+func gradeOrdering(question entity.QuizQuestion, order []int) float64 {
+	if len(question.Choices) == 0 || !isChoicePermutation(question, order) {
+		return 0
+	}
+
+	correctOrder := correctChoiceOrder(question)
+	matches := 0
+	for i, idx := range order {
+		if correctOrder[i] == idx {
+			matches++
+		}
+	}
+
+	if question.ScoringMode == entity.ScoringPerPosition {
+		return float64(matches) / float64(len(correctOrder))
+	}
+	if matches == len(correctOrder) {
+		return 1
+	}
+	return 0
+}
+
+func votePercentages(counts []int) (int, []float64) {
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+
+	percentages := make([]float64, len(counts))
+	if total == 0 {
+		return 0, percentages
+	}
+	for i, count := range counts {
+		percentages[i] = math.Round(float64(count)*1000/float64(total)) / 10
+	}
+	return total, percentages
+}
+
+func selectedChoiceIndexes(question entity.QuizQuestion, answer PlayerAnswer) []int {
+	if question.Type != entity.QuestionTypeMultiple {
+		if answer.Choice < 0 || answer.Choice >= len(question.Choices) {
+			return nil
+		}
+		return []int{answer.Choice}
+	}
+
+	seen := make(map[int]bool)
+	selected := []int{}
+	for _, choice := range answer.Choices {
+		if choice < 0 || choice >= len(question.Choices) || seen[choice] {
+			continue
+		}
+		seen[choice] = true
+		selected = append(selected, choice)
+	}
+	return selected
+}
+
+func gradeAnswer(question entity.QuizQuestion, answer PlayerAnswer) float64 {
+	switch question.Type {
+	case entity.QuestionTypeMultiple:
+		return gradeMultipleChoice(question, selectedChoiceIndexes(question, answer))
+	case entity.QuestionTypeText:
+		if matchesAcceptedAnswer(question, answer.Text) {
+			return 1
+		}
+		return 0
+	case entity.QuestionTypeNumeric:
+		return gradeNumeric(question, answer.Value)
+	case entity.QuestionTypeOrdering:
+		return gradeOrdering(question, answer.Order)
+	default:
+		for _, correctIdx := range correctChoiceIndexes(question) {
+			if answer.Choice == correctIdx {
+				return 1
+			}
+		}
+		return 0
+	}
+}
+
+func gradeMultipleChoice(question entity.QuizQuestion, selected []int) float64 {
+	correctCount := 0
+	for _, choice := range question.Choices {
+		if choice.Correct {
+			correctCount++
+		}
+	}
+	if correctCount == 0 || len(selected) == 0 {
+		return 0
+	}
+
+	hits := 0
+	wrongPicks := 0
+	for _, idx := range selected {
+		if question.Choices[idx].Correct {
+			hits++
+		} else {
+			wrongPicks++
+		}
+	}
+
+	switch question.ScoringMode {
+	case entity.ScoringProportional:
+		correctRejections := len(question.Choices) - correctCount - wrongPicks
+		return float64(hits+correctRejections) / float64(len(question.Choices))
+	case entity.ScoringPenalty:
+		credit := float64(hits-wrongPicks) / float64(correctCount)
+		if credit < 0 {
+			return 0
+		}
+		return credit
+	default:
+		if hits == correctCount && wrongPicks == 0 {
+			return 1
+		}
+		return 0
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"errors"
+	"log"
+)
+
+const maxExtendSeconds = 300
+
+var ErrNoActiveQuestion = errors.New("no question is in progress")
+
+type ExtendTimePacket struct {
+	Seconds int `json:"seconds"`
+}
+
+type EndQuestionPacket struct{}
+
+type SkipQuestionPacket struct {
+	QuestionIndex int `json:"questionIndex"`
+}
+
+func (g *Game) isPaused() bool {
+	g.playersMutex.RLock()
+	defer g.playersMutex.RUnlock()
+	return g.paused || g.hostAway
+}
+
+func (g *Game) SetPaused(paused bool) error {
+	g.clockMutex.Lock()
+	defer g.clockMutex.Unlock()
+	return g.setPausedClockLocked(paused)
+}
+
+func (g *Game) setPausedClockLocked(paused bool) error {
+	if g.State == LobbyState || g.State == EndState {
+		return ErrNoActiveQuestion
+	}
+
+	g.playersMutex.Lock()
+	changed := g.paused != paused
+	g.paused = paused
+	g.playersMutex.Unlock()
+
+	if !changed {
+		return nil
+	}
+
+	log.Printf("Game %s: paused=%t by host", g.Code, paused)
+	g.BroadcastPacket(GamePausePacket{Paused: paused}, true)
+	return nil
+}
+
+func (g *Game) ExtendTime(seconds int) error {
+	g.clockMutex.Lock()
+	defer g.clockMutex.Unlock()
+
+	if g.State != PlayState {
+		return ErrNoActiveQuestion
+	}
+	if seconds <= 0 || seconds > maxExtendSeconds {
+		return errors.New("invalid extend duration")
+	}
+
+	g.Time += seconds
+	log.Printf("Game %s: question %d extended by %ds", g.Code, g.CurrentQuestion, seconds)
+	g.BroadcastPacket(TickPacket{
+		Tick: g.Time,
+	}, true)
+	return nil
+}
+
+func (g *Game) EndQuestionEarly() error {
+	g.clockMutex.Lock()
+	defer g.clockMutex.Unlock()
+
+	if g.State != PlayState {
+		return ErrNoActiveQuestion
+	}
+
+	g.Time = 0
+	return g.setPausedClockLocked(false)
+}
+
+func (g *Game) SkipQuestion() error {
+	g.clockMutex.Lock()
+	defer g.clockMutex.Unlock()
+
+	if g.State != PlayState {
+		return ErrNoActiveQuestion
+	}
+
+	skipped := g.CurrentQuestion
+	g.playersMutex.Lock()
+	g.paused = false
+	for _, player := range g.Players {
+		player.Answered = false
+		player.CurrentAnswer = PlayerAnswer{}
+	}
+	g.playersMutex.Unlock()
+
+	log.Printf("Game %s: question %d skipped by host", g.Code, skipped)
+	g.BroadcastPacket(SkipQuestionPacket{QuestionIndex: skipped}, true)
+
+	if skipped >= len(g.Quiz.Questions)-1 {
+		g.Intermission()
+		return nil
+	}
+	g.NextQuestion()
+	return nil
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"CorrectQuiz.com/quiz/internal/entity"
+)
+
+func startedControlGame(t *testing.T) (*Game, *Client) {
+	t.Helper()
+	netService, err := Net(nil, nil, DefaultCodeOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostConnection, _ := captureConnection(netService)
+	first := leakTestQuestion(entity.QuestionTypeSingle)
+	second := leakTestQuestion(entity.QuestionTypeSingle)
+	second.Time = 30
+	game := newGame(entity.Quiz{
+		Name:      "host controls",
+		Questions: []entity.QuizQuestion{first, second},
+	}, hostConnection, netService)
+	t.Cleanup(game.cancelFunc)
+
+	playerConnection, playerClient := captureConnection(netService)
+	game.OnPlayerJoin("player", playerConnection)
+	game.Start()
+	drainPackets(playerClient)
+	return &game, playerClient
+}
+
+func TestHostControlsRequireActiveQuestion(t *testing.T) {
+	netService, err := Net(nil, nil, DefaultCodeOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	game := newGame(entity.Quiz{Questions: []entity.QuizQuestion{leakTestQuestion(entity.QuestionTypeSingle)}}, nil, netService)
+	defer game.cancelFunc()
+
+	if err := game.SetPaused(true); err != ErrNoActiveQuestion {
+		t.Errorf("SetPaused in lobby = %v, want ErrNoActiveQuestion", err)
+	}
+	if err := game.ExtendTime(10); err != ErrNoActiveQuestion {
+		t.Errorf("ExtendTime in lobby = %v, want ErrNoActiveQuestion", err)
+	}
+	if err := game.EndQuestionEarly(); err != ErrNoActiveQuestion {
+		t.Errorf("EndQuestionEarly in lobby = %v, want ErrNoActiveQuestion", err)
+	}
+	if err := game.SkipQuestion(); err != ErrNoActiveQuestion {
+		t.Errorf("SkipQuestion in lobby = %v, want ErrNoActiveQuestion", err)
+	}
+}
+
+func TestPauseStopsTheClock(t *testing.T) {
+	game, playerClient := startedControlGame(t)
+
+	if err := game.SetPaused(true); err != nil {
+		t.Fatal(err)
+	}
+	if ids := packetIds(drainPackets(playerClient)); !slices.Contains(ids, 19) {
+		t.Errorf("players were not told the game paused, got %v", ids)
+	}
+	before := game.Time
+	game.Tick()
+	if game.Time != before {
+		t.Errorf("paused tick moved time from %d to %d", before, game.Time)
+	}
+
+	if err := game.SetPaused(false); err != nil {
+		t.Fatal(err)
+	}
+	game.Tick()
+	if game.Time != before-1 {
+		t.Errorf("resumed tick left time at %d, want %d", game.Time, before-1)
+	}
+}
+
+func TestExtendTime(t *testing.T) {
+	tests := []struct {
+		name    string
+		seconds int
+		wantErr bool
+	}{
+		{"ten seconds", 10, false},
+		{"at the cap", maxExtendSeconds, false},
+		{"over the cap", maxExtendSeconds + 1, true},
+		{"zero", 0, true},
+		{"negative", -5, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, playerClient := startedControlGame(t)
+			before := game.Time
+
+			err := game.ExtendTime(tt.seconds)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ExtendTime(%d) succeeded, want an error", tt.seconds)
+				}
+				if game.Time != before {
+					t.Errorf("rejected extend changed time from %d to %d", before, game.Time)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if game.Time != before+tt.seconds {
+				t.Errorf("time = %d, want %d", game.Time, before+tt.seconds)
+			}
+			if ids := packetIds(drainPackets(playerClient)); !slices.Contains(ids, 6) {
+				t.Errorf("players were not sent the new time, got %v", ids)
+			}
+		})
+	}
+}
+
+func TestEndQuestionEarlyRevealsOnNextTick(t *testing.T) {
+	game, _ := startedControlGame(t)
+	game.SetPaused(true)
+
+	if err := game.EndQuestionEarly(); err != nil {
+		t.Fatal(err)
+	}
+	if game.Time != 0 || game.isPaused() {
+		t.Fatalf("time = %d paused = %v, want 0 and unpaused", game.Time, game.isPaused())
+	}
+	game.Tick()
+	if game.State != RevealState {
+		t.Errorf("state = %d, want RevealState", game.State)
+	}
+}
+
+func TestSkipQuestion(t *testing.T) {
+	game, playerClient := startedControlGame(t)
+	player := game.Players[0]
+	game.OnPlayerAnswer(0, PlayerAnswer{Choice: 2}, player)
+	game.SetPaused(true)
+	drainPackets(playerClient)
+
+	if err := game.SkipQuestion(); err != nil {
+		t.Fatal(err)
+	}
+	if game.CurrentQuestion != 1 || game.State != PlayState || game.Time != 30 {
+		t.Errorf("after skip: question %d state %d time %d, want 1 PlayState 30", game.CurrentQuestion, game.State, game.Time)
+	}
+	if player.Answered || game.isPaused() {
+		t.Errorf("after skip: answered = %v paused = %v, want both false", player.Answered, game.isPaused())
+	}
+	if player.Points != 0 {
+		t.Errorf("skipped question awarded %d points", player.Points)
+	}
+	ids := packetIds(drainPackets(playerClient))
+	if !slices.Contains(ids, 27) || !slices.Contains(ids, 2) {
+		t.Errorf("players were not sent the skip and the next question, got %v", ids)
+	}
+
+	if err := game.SkipQuestion(); err != nil {
+		t.Fatal(err)
+	}
+	if game.State != EndState {
+		t.Errorf("skipping the last question left state %d, want EndState", game.State)
+	}
+}
+
+func TestHostControlsDoNotRaceWithTick(t *testing.T) {
+	game, _ := startedControlGame(t)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			game.Tick()
+		}
+	}()
+	for i := 0; i < 200; i++ {
+		game.ExtendTime(1)
+	}
+	wg.Wait()
+}
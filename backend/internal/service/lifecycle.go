@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+const (
+	DefaultLobbyIdleTTL  = 30 * time.Minute
+	DefaultActiveIdleTTL = 2 * time.Hour
+	DefaultFinishedTTL   = 10 * time.Minute
+	DefaultSweepInterval = time.Minute
+)
+
+type LifecycleOptions struct {
+	LobbyIdleTTL  time.Duration
+	ActiveIdleTTL time.Duration
+	FinishedTTL   time.Duration
+	SweepInterval time.Duration
+}
+
+type GameStats struct {
+	Live        int   `json:"live"`
+	Lobby       int   `json:"lobby"`
+	Playing     int   `json:"playing"`
+	Finished    int   `json:"finished"`
+	Assignments int   `json:"assignments"`
+	Reaped      int64 `json:"reaped"`
+}
+
+func DefaultLifecycleOptions() LifecycleOptions {
+	return LifecycleOptions{
+		LobbyIdleTTL:  DefaultLobbyIdleTTL,
+		ActiveIdleTTL: DefaultActiveIdleTTL,
+		FinishedTTL:   DefaultFinishedTTL,
+		SweepInterval: DefaultSweepInterval,
+	}
+}
+
+func (g *Game) touch() {
+	g.lastActivity.Store(time.Now().UnixNano())
+}
+
+func (g *Game) idleFor(now time.Time) time.Duration {
+	return now.Sub(time.Unix(0, g.lastActivity.Load()))
+}
+
+func (o LifecycleOptions) expired(game *Game, now time.Time) bool {
+	if game.IsSelfPaced() {
+		return false
+	}
+
+	idle := game.idleFor(now)
+	switch game.State {
+	case LobbyState:
+		return idle >= o.LobbyIdleTTL
+	case EndState, GameEndedState:
+		return idle >= o.FinishedTTL
+	default:
+		return idle >= o.ActiveIdleTTL
+	}
+}
+
+func (c *NetService) StartReaper(ctx context.Context, options LifecycleOptions) {
+	go func() {
+		ticker := time.NewTicker(options.SweepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.reapIdleGames(options, time.Now())
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (c *NetService) reapIdleGames(options LifecycleOptions, now time.Time) {
+	for _, game := range c.games.All() {
+		if !options.expired(game, now) {
+			continue
+		}
+
+		idle := game.idleFor(now)
+		if !c.endGame(game) {
+			continue
+		}
+		log.Printf("🧹 Game %s: reaped after %s idle (state %d)", game.Code, idle.Round(time.Second), game.State)
+		c.reapedGames.Add(1)
+
+		game.playersMutex.RLock()
+		hostConnection := game.Host
+		game.playersMutex.RUnlock()
+
+		if hostConnection != nil {
+			c.SendPacket(hostConnection, ChangeGameStatePacket{
+				State: GameEndedState,
+			})
+			c.CloseConnection(hostConnection)
+		}
+	}
+}
+
+func (c *NetService) Stats() GameStats {
+	stats := GameStats{
+		Reaped: c.reapedGames.Load(),
+	}
+	for _, game := range c.games.All() {
+		stats.Live++
+		switch {
+		case game.IsSelfPaced():
+			stats.Assignments++
+		case game.State == LobbyState:
+			stats.Lobby++
+		case game.State == EndState || game.State == GameEndedState:
+			stats.Finished++
+		default:
+			stats.Playing++
+		}
+	}
+	return stats
+}
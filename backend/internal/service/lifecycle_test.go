@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func shortLifecycleOptions() LifecycleOptions {
+	return LifecycleOptions{
+		LobbyIdleTTL:  time.Millisecond,
+		ActiveIdleTTL: time.Millisecond,
+		FinishedTTL:   time.Millisecond,
+		SweepInterval: time.Millisecond,
+	}
+}
+
+func TestReapIdleGamesEndsGameOnce(t *testing.T) {
+	netService, err := Net(nil, nil, DefaultCodeOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	game, hostClient := registeredGame(t, netService)
+	game.hostReconnectGrace = 10 * time.Millisecond
+	playerConnection, playerClient := captureConnection(netService)
+	game.OnPlayerJoin("player", playerConnection)
+	drainPackets(hostClient)
+	drainPackets(playerClient)
+
+	netService.reapIdleGames(shortLifecycleOptions(), time.Now().Add(time.Second))
+
+	if netService.GetGameByCode(game.Code) != nil {
+		t.Fatal("idle game was not removed")
+	}
+	if got := netService.Stats().Reaped; got != 1 {
+		t.Errorf("reaped = %d, want 1", got)
+	}
+	if ids := packetIds(drainPackets(hostClient)); !slices.Equal(ids, []byte{3}) {
+		t.Errorf("host got packets %v, want only the game ended state", ids)
+	}
+	if ids := packetIds(drainPackets(playerClient)); !slices.Equal(ids, []byte{3}) {
+		t.Errorf("player got packets %v, want only the game ended state", ids)
+	}
+
+	netService.OnDisconnect(game.Host)
+	time.Sleep(5 * game.hostReconnectGrace)
+	if game.hostAway {
+		t.Error("closing the reaped host connection started a reconnect grace period")
+	}
+	if ids := packetIds(drainPackets(playerClient)); len(ids) != 0 {
+		t.Errorf("player got packets %v after the game was reaped", ids)
+	}
+	if netService.endGame(game) {
+		t.Error("endGame ended an already reaped game")
+	}
+
+	netService.reapIdleGames(shortLifecycleOptions(), time.Now().Add(time.Second))
+	if got := netService.Stats().Reaped; got != 1 {
+		t.Errorf("reaped = %d after a second sweep, want 1", got)
+	}
+}
+
+func TestReapIdleGamesKeepsActiveGames(t *testing.T) {
+	netService, err := Net(nil, nil, DefaultCodeOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	game, _ := registeredGame(t, netService)
+
+	options := shortLifecycleOptions()
+	options.LobbyIdleTTL = time.Hour
+	netService.reapIdleGames(options, time.Now())
+
+	if netService.GetGameByCode(game.Code) != game {
+		t.Error("game within its idle timeout was reaped")
+	}
+	if got := netService.Stats().Reaped; got != 0 {
+		t.Errorf("reaped = %d, want 0", got)
+	}
+}
+
+func TestStartReaperRemovesIdleGames(t *testing.T) {
+	netService, err := Net(nil, nil, DefaultCodeOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	game, _ := registeredGame(t, netService)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	netService.StartReaper(ctx, shortLifecycleOptions())
+
+	if !waitForGameRemoval(netService, game, time.Second) {
+		t.Fatal("reaper did not remove the idle game")
+	}
+}
@@ -1,43 +1,67 @@
 package service
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"unicode/utf8"
 
-	"CorrectQuiz.com/quiz/internal/entity"
 	"github.com/gofiber/contrib/websocket"
 	"github.com/google/uuid"
 )
 
 type NetService struct {
-	quizService *QuizService
-	games       []*Game
-	gamesMutex  sync.RWMutex
+	quizService    *QuizService
+	sessionService *GameSessionService
+	games          *GameRegistry
+	reapedGames    atomic.Int64
+	clientsMutex   sync.RWMutex
+	clients        map[*websocket.Conn]*Client
 }
 
-func Net(quizService *QuizService) *NetService {
-	return &NetService{
-		quizService: quizService,
-		games:       []*Game{},
-		gamesMutex:  sync.RWMutex{},
+func Net(quizService *QuizService, sessionService *GameSessionService, codeOptions CodeOptions) (*NetService, error) {
+	games, err := NewGameRegistry(codeOptions)
+	if err != nil {
+		return nil, err
 	}
+	return &NetService{
+		quizService:    quizService,
+		sessionService: sessionService,
+		games:          games,
+		clients:        make(map[*websocket.Conn]*Client),
+	}, nil
 }
 
 type ConnectPacket struct {
-	Code string `json:"code"`
-	Name string `json:"name"`
+	Code        string `json:"code"`
+	Name        string `json:"name"`
+	ResumeToken string `json:"resumeToken,omitempty"`
 }
 
 type HostGamePacket struct {
-	QuizId string `json:"quizId"`
+	QuizId      string            `json:"quizId"`
+	ResumeToken string            `json:"resumeToken,omitempty"`
+	Teams       *TeamOptions      `json:"teams,omitempty"`
+	AutoPilot   *AutoPilotOptions `json:"autoPilot,omitempty"`
 }
 
 type QuestionShowPacket struct {
-	Question      entity.QuizQuestion `json:"question"`
-	QuestionIndex int                 `json:"questionIndex"`
+	Question         PlayerQuestion `json:"question"`
+	QuestionIndex    int            `json:"questionIndex"`
+	PointsMultiplier float64        `json:"pointsMultiplier"`
+	BonusRound       bool           `json:"bonusRound"`
+	SelfPaced        bool           `json:"selfPaced,omitempty"`
+}
+
+type HostQuestionShowPacket struct {
+	Question         HostQuestion `json:"question"`
+	QuestionIndex    int          `json:"questionIndex"`
+	PointsMultiplier float64      `json:"pointsMultiplier"`
+	BonusRound       bool         `json:"bonusRound"`
 }
 
 type ChangeGameStatePacket struct {
@@ -57,8 +81,22 @@ type TickPacket struct {
 }
 
 type QuestionAnswerPacket struct {
-	Question int `json:"question"`
-	Choice   int `json:"choice"`
+	Question int      `json:"question"`
+	Choice   int      `json:"choice"`
+	Choices  []int    `json:"choices,omitempty"`
+	Text     string   `json:"text,omitempty"`
+	Value    *float64 `json:"value,omitempty"`
+	Order    []int    `json:"order,omitempty"`
+}
+
+func (p QuestionAnswerPacket) answer() PlayerAnswer {
+	return PlayerAnswer{
+		Choice:  p.Choice,
+		Choices: p.Choices,
+		Text:    p.Text,
+		Value:   p.Value,
+		Order:   p.Order,
+	}
 }
 
 type PlayerRevealPacket struct {
@@ -66,13 +104,18 @@ type PlayerRevealPacket struct {
 }
 
 type QuestionRevealPacket struct {
-	Question           entity.QuizQuestion `json:"question"`
-	CorrectAnswerIndex []int               `json:"correctAnswerIndex"`
-	AnswerCounts       []int               `json:"answerCounts"`
+	Question               HostQuestion      `json:"question"`
+	CorrectAnswerIndex     []int             `json:"correctAnswerIndex"`
+	AnswerCounts           []int             `json:"answerCounts"`
+	TextAnswers            []TextAnswerCount `json:"textAnswers,omitempty"`
+	Histogram              []HistogramBucket `json:"histogram,omitempty"`
+	CorrectPlacementCounts []int             `json:"correctPlacementCounts,omitempty"`
+	Percentages            []float64         `json:"percentages,omitempty"`
 }
 
 type LeaderboardPacket struct {
 	Points []LeaderboardEntry `json:"points"`
+	Teams  []TeamStanding     `json:"teams,omitempty"`
 }
 
 type PlayerLeavePacket struct {
@@ -80,7 +123,9 @@ type PlayerLeavePacket struct {
 }
 
 type PlayerRankPacket struct {
-	Rank int `json:"rank"`
+	Rank     int `json:"rank"`
+	Team     int `json:"team,omitempty"`
+	TeamRank int `json:"teamRank,omitempty"`
 }
 
 type KickPlayerPacket struct {
@@ -89,6 +134,20 @@ type KickPlayerPacket struct {
 
 type HostLeavePacket struct{}
 
+const (
+	ErrorCodeGameNotFound   = "game_not_found"
+	ErrorCodeNameTaken      = "name_taken"
+	ErrorCodeGameStarted    = "game_started"
+	ErrorCodeUnauthorized   = "unauthorized"
+	ErrorCodeInvalidPayload = "invalid_payload"
+	ErrorCodeQuizNotFound   = "quiz_not_found"
+)
+
+type ErrorPacket struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
 type NextQuestionPacket struct{}
 
 func (c *NetService) packetIdtoPacket(packetId uint8) any {
@@ -144,6 +203,30 @@ func (c *NetService) packetIdtoPacket(packetId uint8) any {
 		{
 			return &PlayerLeavePacket{}
 		}
+	case 19:
+		{
+			return &GamePausePacket{}
+		}
+	case 24:
+		{
+			return &JoinTeamPacket{}
+		}
+	case 25:
+		{
+			return &ExtendTimePacket{}
+		}
+	case 26:
+		{
+			return &EndQuestionPacket{}
+		}
+	case 27:
+		{
+			return &SkipQuestionPacket{}
+		}
+	case 29:
+		{
+			return &HelloPacket{}
+		}
 	}
 
 	return nil
@@ -155,7 +238,7 @@ func (c *NetService) packettoPacketId(packet any) (uint8, error) {
 		{
 			return 1, nil
 		}
-	case QuestionShowPacket:
+	case QuestionShowPacket, HostQuestionShowPacket:
 		{
 			return 2, nil
 		}
@@ -199,26 +282,86 @@ func (c *NetService) packettoPacketId(packet any) (uint8, error) {
 		{
 			return 17, nil
 		}
+	case ResumeTokenPacket:
+		{
+			return 18, nil
+		}
+	case GamePausePacket:
+		{
+			return 19, nil
+		}
+	case GameSnapshotPacket:
+		{
+			return 20, nil
+		}
+	case PollResultsPacket:
+		{
+			return 21, nil
+		}
+	case WordCloudPacket:
+		{
+			return 22, nil
+		}
+	case TeamAssignPacket:
+		{
+			return 23, nil
+		}
+	case SkipQuestionPacket:
+		{
+			return 27, nil
+		}
+	case ErrorPacket:
+		{
+			return 28, nil
+		}
+	case HelloPacket:
+		{
+			return 29, nil
+		}
+	case ProtocolRejectPacket:
+		{
+			return 30, nil
+		}
 	}
 	return 0, errors.New("invalid packet type")
 }
 
 func (c *NetService) GetGameByCode(code string) *Game {
-	c.gamesMutex.RLock()
-	defer c.gamesMutex.RUnlock()
-	for _, game := range c.games {
-		if game.Code == code {
-			return game
-		}
+	return c.games.ByCode(code)
+}
+
+func (c *NetService) GetGameById(id uuid.UUID) *Game {
+	return c.games.ById(id)
+}
+
+func (c *NetService) connectionUserID(con *websocket.Conn) uint {
+	if client := c.client(con); client != nil {
+		return client.userID
 	}
-	return nil
+	return 0
+}
+
+func (c *NetService) sendError(con *websocket.Conn, code string, message string) {
+	c.SendPacket(con, ErrorPacket{
+		Code:    code,
+		Message: message,
+	})
+}
+
+func (c *NetService) hostGame(con *websocket.Conn) *Game {
+	game := c.GetGameByHost(con)
+	if game == nil {
+		c.sendError(con, ErrorCodeUnauthorized, "Only the host can control the game")
+	}
+	return game
 }
 
 func (c *NetService) GetGameByHost(host *websocket.Conn) *Game {
-	c.gamesMutex.RLock()
-	defer c.gamesMutex.RUnlock()
-	for _, game := range c.games {
-		if game.Host == host {
+	for _, game := range c.games.All() {
+		game.playersMutex.RLock()
+		isHost := game.Host == host
+		game.playersMutex.RUnlock()
+		if isHost {
 			return game
 		}
 	}
@@ -226,9 +369,7 @@ func (c *NetService) GetGameByHost(host *websocket.Conn) *Game {
 }
 
 func (c *NetService) GetGameByPlayer(con *websocket.Conn) (*Game, *Player) {
-	c.gamesMutex.RLock()
-	defer c.gamesMutex.RUnlock()
-	for _, game := range c.games {
+	for _, game := range c.games.All() {
 		game.playersMutex.RLock()
 		for _, player := range game.Players {
 			if player.Connection == con {
@@ -261,53 +402,55 @@ func (c *NetService) IsNameTakenInGame(code string, name string) bool {
 }
 
 func (c *NetService) FindActiveGameByCode(code string) (*Game, error) {
-	c.gamesMutex.RLock()
-	defer c.gamesMutex.RUnlock()
-
-	for _, game := range c.games {
-		if game.Code == code {
-			return game, nil
-		}
+	game := c.games.ByCode(code)
+	if game == nil {
+		return nil, errors.New("active game not found")
 	}
-	return nil, errors.New("active game not found")
+	return game, nil
 }
 
 func (c *NetService) handleHostLeave(con *websocket.Conn) {
 	game := c.GetGameByHost(con)
-
 	if game != nil {
-		endPacket := ChangeGameStatePacket{
-			State: GameEndedState,
-		}
+		c.endGame(game)
+	}
+}
 
-		if game.cancelFunc != nil {
-			game.cancelFunc()
-		}
+func (c *NetService) endGame(game *Game) bool {
+	if !c.games.Remove(game) {
+		return false
+	}
 
-		for _, p := range game.Players {
-			if p.Connection != nil {
-				err := c.SendPacket(p.Connection, endPacket)
-				if err != nil {
-				}
+	endPacket := ChangeGameStatePacket{
+		State: GameEndedState,
+	}
 
-				p.Connection.Close()
-			}
-		}
-		c.gamesMutex.Lock()
-		var newGames []*Game
-		for _, g := range c.games {
-			if g.Id != game.Id {
-				newGames = append(newGames, g)
-			}
+	if game.cancelFunc != nil {
+		game.cancelFunc()
+	}
+	game.endSession()
+
+	game.playersMutex.RLock()
+	for _, p := range game.Players {
+		if p.Connection != nil {
+			c.SendPacket(p.Connection, endPacket)
+			c.CloseConnection(p.Connection)
 		}
-		c.games = newGames
-		c.gamesMutex.Unlock()
 	}
+	game.playersMutex.RUnlock()
+	return true
 }
 
 func (c *NetService) handlePlayerLeave(con *websocket.Conn, playerId uuid.UUID) {
 	game, player := c.GetGameByPlayerId(playerId)
 	if game != nil && player != nil {
+		game.playersMutex.RLock()
+		allowed := player.Connection == con || game.Host == con
+		game.playersMutex.RUnlock()
+		if !allowed {
+			c.sendError(con, ErrorCodeUnauthorized, "Cannot remove another player")
+			return
+		}
 
 		game.RemovePlayer(player.Id)
 
@@ -321,26 +464,90 @@ func (c *NetService) handlePlayerLeave(con *websocket.Conn, playerId uuid.UUID)
 	}
 }
 
+func (c *NetService) resumablePlayer(resumeToken string) (*Game, *Player) {
+	claims, err := parseResumeToken(resumeToken)
+	if err != nil || claims.Host {
+		return nil, nil
+	}
+
+	game := c.GetGameById(claims.GameId)
+	if game == nil {
+		return nil, nil
+	}
+
+	player := game.GetPlayer(claims.PlayerId)
+	if player == nil {
+		return nil, nil
+	}
+	return game, player
+}
+
+func (c *NetService) CanResume(code string, resumeToken string) bool {
+	if resumeToken == "" {
+		return false
+	}
+	game, _ := c.resumablePlayer(resumeToken)
+	return game != nil && game.Code == code
+}
+
+func (c *NetService) resumePlayer(con *websocket.Conn, resumeToken string) bool {
+	game, player := c.resumablePlayer(resumeToken)
+	if game == nil {
+		return false
+	}
+
+	game.OnPlayerResume(player, con)
+	return true
+}
+
+func (c *NetService) resumeHost(con *websocket.Conn, resumeToken string) bool {
+	claims, err := parseResumeToken(resumeToken)
+	if err != nil || !claims.Host {
+		return false
+	}
+
+	game := c.GetGameById(claims.GameId)
+	if game == nil || game.HostUserID != c.connectionUserID(con) {
+		return false
+	}
+
+	game.OnHostResume(con)
+	return true
+}
+
 func (c *NetService) OnIncomingMessage(con *websocket.Conn, mt int, msg []byte) {
 
 	fmt.Printf("📦 [Backend] OnIncomingMessage: Type=%d Len=%d\n", mt, len(msg))
 
 	if len(msg) < 2 {
+		c.sendError(con, ErrorCodeInvalidPayload, "Packet is too short")
 		return
 	}
 
 	packetId := msg[0]
 	data := msg[1:]
 
+	if packetId != helloPacketId && !c.handshakeCompleted(con) {
+		c.rejectProtocol(con, "Handshake required before sending packets")
+		return
+	}
+
 	packet := c.packetIdtoPacket(packetId)
 	if packet == nil {
 		fmt.Printf("❌ Unknown Packet ID: %d\n", packetId)
+		c.sendError(con, ErrorCodeInvalidPayload, fmt.Sprintf("Unknown packet id %d", packetId))
 		return
 	}
 
-	err := json.Unmarshal(data, packet)
+	err := c.connectionCodec(con).Unmarshal(data, packet)
 	if err != nil {
 		fmt.Println("❌ Unmarshal Error:", err)
+		c.sendError(con, ErrorCodeInvalidPayload, "Malformed packet data")
+		return
+	}
+
+	if hello, ok := packet.(*HelloPacket); ok {
+		c.onHello(con, hello)
 		return
 	}
 
@@ -349,8 +556,35 @@ func (c *NetService) OnIncomingMessage(con *websocket.Conn, mt int, msg []byte)
 	switch data := packet.(type) {
 	case *ConnectPacket:
 		{
+			if data.ResumeToken != "" && c.resumePlayer(con, data.ResumeToken) {
+				break
+			}
+
 			game := c.GetGameByCode(data.Code)
 			if game == nil {
+				c.sendError(con, ErrorCodeGameNotFound, "Game not found")
+				return
+			}
+			if strings.TrimSpace(data.Name) == "" {
+				c.sendError(con, ErrorCodeInvalidPayload, "Name is required")
+				return
+			}
+			if c.IsNameTakenInGame(game.Code, data.Name) {
+				c.sendError(con, ErrorCodeNameTaken, "Name is already taken")
+				return
+			}
+			if game.IsSelfPaced() {
+				if !game.AssignmentOpen() {
+					log.Printf("Game %s: join rejected, assignment is not open", game.Code)
+					c.sendError(con, ErrorCodeGameStarted, "Assignment is not open")
+					return
+				}
+				player := game.OnPlayerJoin(data.Name, con)
+				game.showSelfPacedQuestion(player)
+				break
+			}
+			if game.State != LobbyState {
+				c.sendError(con, ErrorCodeGameStarted, "Game has already started")
 				return
 			}
 			game.OnPlayerJoin(data.Name, con)
@@ -361,9 +595,27 @@ func (c *NetService) OnIncomingMessage(con *websocket.Conn, mt int, msg []byte)
 		{
 			fmt.Println("🏠 HostGame Request received!")
 
+			if data.ResumeToken != "" {
+				if c.resumeHost(con, data.ResumeToken) {
+					fmt.Println("✅ Host Resumed")
+					break
+				}
+				if data.QuizId == "" {
+					c.sendError(con, ErrorCodeGameNotFound, "Game to resume was not found")
+					return
+				}
+			}
+
+			userID := c.connectionUserID(con)
+			if userID == 0 {
+				c.sendError(con, ErrorCodeUnauthorized, "Login is required to host a game")
+				return
+			}
+
 			id64, err := strconv.ParseUint(data.QuizId, 10, 64)
 			if err != nil {
 				fmt.Println("❌ Invalid Quiz ID:", err)
+				c.sendError(con, ErrorCodeInvalidPayload, "Invalid quiz id")
 				return
 			}
 			quizId := uint(id64)
@@ -371,18 +623,32 @@ func (c *NetService) OnIncomingMessage(con *websocket.Conn, mt int, msg []byte)
 			quiz, err := c.quizService.GetQuizById(quizId)
 			if err != nil {
 				fmt.Println("❌ DB Error:", err)
+				c.sendError(con, ErrorCodeQuizNotFound, "Quiz not found")
 				return
 			}
 
 			if quiz == nil {
 				fmt.Println("❌ Quiz not found (ID:", quizId, ")")
+				c.sendError(con, ErrorCodeQuizNotFound, "Quiz not found")
+				return
+			}
+
+			if quiz.UserID != uint64(userID) {
+				log.Printf("Quiz %d: user %d tried to host a quiz they do not own", quizId, userID)
+				c.sendError(con, ErrorCodeUnauthorized, "You can only host your own quizzes")
 				return
 			}
 
 			game := newGame(*quiz, con, c)
-			c.gamesMutex.Lock()
-			c.games = append(c.games, &game)
-			c.gamesMutex.Unlock()
+			game.HostUserID = userID
+			if data.Teams.valid() {
+				game.Teams = data.Teams
+			}
+			game.AutoPilot = data.AutoPilot.normalize()
+			if err := c.games.Register(&game); err != nil {
+				log.Printf("Quiz %d: failed to register game: %v", quizId, err)
+				return
+			}
 
 			c.SendPacket(con, HostGamePacket{
 				QuizId: game.Code,
@@ -392,6 +658,7 @@ func (c *NetService) OnIncomingMessage(con *websocket.Conn, mt int, msg []byte)
 				State: game.State,
 				Code:  game.Code,
 			})
+			game.sendHostResumeToken()
 			fmt.Println("✅ Game Room Created:", game.Code)
 			break
 		}
@@ -399,18 +666,19 @@ func (c *NetService) OnIncomingMessage(con *websocket.Conn, mt int, msg []byte)
 	case *StartGamePacket:
 		{
 			fmt.Println("🚀 StartGame Request received!")
-			game := c.GetGameByHost(con)
+			game := c.hostGame(con)
 			if game == nil {
-				fmt.Println("❌ Game not found for this host")
 				return
 			}
+			game.clockMutex.Lock()
 			game.Start()
+			game.clockMutex.Unlock()
 			fmt.Println("✅ Game Started!")
 			break
 		}
 	case *KickPlayerPacket:
 		{
-			game := c.GetGameByHost(con)
+			game := c.hostGame(con)
 			if game == nil {
 				return
 			}
@@ -424,6 +692,7 @@ func (c *NetService) OnIncomingMessage(con *websocket.Conn, mt int, msg []byte)
 			}
 
 			if playerToKick == nil {
+				c.sendError(con, ErrorCodeInvalidPayload, "Player not found")
 				return
 			}
 
@@ -435,33 +704,113 @@ func (c *NetService) OnIncomingMessage(con *websocket.Conn, mt int, msg []byte)
 		{
 			game, player := c.GetGameByPlayer(con)
 			if game == nil {
+				c.sendError(con, ErrorCodeGameNotFound, "You are not in a game")
+				return
+			}
+			if utf8.RuneCountInString(data.Text) > MaxTextAnswerRunes {
+				c.sendError(con, ErrorCodeInvalidPayload, fmt.Sprintf("Answer text is limited to %d characters", MaxTextAnswerRunes))
 				return
 			}
 
-			game.OnPlayerAnswer(data.Question, data.Choice, player)
+			game.OnPlayerAnswer(data.Question, data.answer(), player)
 			break
 
 		}
 
 	case *ChangeGameStatePacket:
 		{
-			game := c.GetGameByHost(con)
+			game := c.hostGame(con)
 			if game == nil {
 				return
 			}
 
 			if data.State == IntermissionState {
+				game.clockMutex.Lock()
 				game.Intermission()
+				game.clockMutex.Unlock()
 			}
 			break
 		}
 	case *NextQuestionPacket:
 		{
-			game := c.GetGameByHost(con)
+			if game, player := c.GetGameByPlayer(con); game != nil && game.IsSelfPaced() {
+				game.AdvanceSelfPaced(player)
+				break
+			}
+
+			game := c.hostGame(con)
 			if game == nil {
 				return
 			}
+			game.clockMutex.Lock()
 			game.NextQuestion()
+			game.clockMutex.Unlock()
+			break
+		}
+	case *JoinTeamPacket:
+		{
+			game, player := c.GetGameByPlayer(con)
+			if game == nil {
+				c.sendError(con, ErrorCodeGameNotFound, "You are not in a game")
+				return
+			}
+
+			if err := game.JoinTeam(player, data.Team); err != nil {
+				log.Printf("Game %s: join team rejected for player %s: %v", game.Code, player.Id, err)
+				c.sendError(con, ErrorCodeInvalidPayload, err.Error())
+			}
+			break
+		}
+	case *GamePausePacket:
+		{
+			game := c.hostGame(con)
+			if game == nil {
+				return
+			}
+
+			if err := game.SetPaused(data.Paused); err != nil {
+				log.Printf("Game %s: pause rejected: %v", game.Code, err)
+				c.sendError(con, ErrorCodeInvalidPayload, err.Error())
+			}
+			break
+		}
+	case *ExtendTimePacket:
+		{
+			game := c.hostGame(con)
+			if game == nil {
+				return
+			}
+
+			if err := game.ExtendTime(data.Seconds); err != nil {
+				log.Printf("Game %s: extend time rejected: %v", game.Code, err)
+				c.sendError(con, ErrorCodeInvalidPayload, err.Error())
+			}
+			break
+		}
+	case *EndQuestionPacket:
+		{
+			game := c.hostGame(con)
+			if game == nil {
+				return
+			}
+
+			if err := game.EndQuestionEarly(); err != nil {
+				log.Printf("Game %s: end question rejected: %v", game.Code, err)
+				c.sendError(con, ErrorCodeInvalidPayload, err.Error())
+			}
+			break
+		}
+	case *SkipQuestionPacket:
+		{
+			game := c.hostGame(con)
+			if game == nil {
+				return
+			}
+
+			if err := game.SkipQuestion(); err != nil {
+				log.Printf("Game %s: skip question rejected: %v", game.Code, err)
+				c.sendError(con, ErrorCodeInvalidPayload, err.Error())
+			}
 			break
 		}
 	case *PlayerLeavePacket:
@@ -478,10 +827,7 @@ func (c *NetService) OnIncomingMessage(con *websocket.Conn, mt int, msg []byte)
 }
 
 func (c *NetService) GetGameByPlayerId(playerId uuid.UUID) (*Game, *Player) {
-	c.gamesMutex.RLock()
-	defer c.gamesMutex.RUnlock()
-
-	for _, game := range c.games {
+	for _, game := range c.games.All() {
 		game.playersMutex.RLock()
 		for _, player := range game.Players {
 			if player.Id == playerId {
@@ -494,69 +840,103 @@ func (c *NetService) GetGameByPlayerId(playerId uuid.UUID) (*Game, *Player) {
 	return nil, nil
 }
 
+func (c *NetService) Connect(con *websocket.Conn) *Client {
+	client := newClient(con)
+
+	c.clientsMutex.Lock()
+	c.clients[con] = client
+	c.clientsMutex.Unlock()
+
+	go client.writePump()
+	return client
+}
+
+func (c *NetService) client(con *websocket.Conn) *Client {
+	c.clientsMutex.RLock()
+	defer c.clientsMutex.RUnlock()
+	return c.clients[con]
+}
+
+func (c *NetService) CloseConnection(con *websocket.Conn) {
+	if client := c.client(con); client != nil {
+		client.CloseGracefully()
+	}
+}
+
 func (c *NetService) OnDisconnect(con *websocket.Conn) {
-	game, player := c.GetGameByPlayer(con)
-	if game != nil && player != nil {
+	c.clientsMutex.Lock()
+	client := c.clients[con]
+	delete(c.clients, con)
+	c.clientsMutex.Unlock()
 
-		game.RemovePlayer(player.Id)
-		leavePacket := PlayerLeavePacket{PlayerId: player.Id}
+	if client != nil {
+		client.Close()
+	}
 
-		err := c.SendPacket(game.Host, leavePacket)
-		if err != nil {
-		}
+	game, player := c.GetGameByPlayer(con)
+	if game != nil && player != nil {
+		game.OnPlayerDisconnect(player)
 		return
 	}
 
 	game = c.GetGameByHost(con)
 	if game != nil {
+		game.OnHostDisconnect()
+	}
+}
 
-		if game.cancelFunc != nil {
-			game.cancelFunc()
-		}
-
-		endPacket := ChangeGameStatePacket{
-			State: GameEndedState,
-		}
+func (c *NetService) SendPacket(connection *websocket.Conn, packet any) error {
+	if connection == nil {
+		return ErrClientClosed
+	}
+	client := c.client(connection)
+	if client == nil {
+		return ErrClientClosed
+	}
+	bytes, err := c.PacketToBytes(client.Codec(), packet)
+	if err != nil {
+		return err
+	}
+	return client.Send(bytes)
+}
 
-		game.playersMutex.RLock()
-		for _, p := range game.Players {
-			if p.Connection != nil {
-				c.SendPacket(p.Connection, endPacket)
-				p.Connection.Close()
-			}
+func (c *NetService) SendPacketToAll(connections []*websocket.Conn, packet any) {
+	encoded := make(map[string][]byte)
+	for _, connection := range connections {
+		client := c.client(connection)
+		if client == nil {
+			continue
 		}
-		game.playersMutex.RUnlock()
-
-		c.gamesMutex.Lock()
-		var newGames []*Game
 
-		for _, g := range c.games {
-			if g.Code != game.Code {
-				newGames = append(newGames, g)
+		codec := client.Codec()
+		bytes, ok := encoded[codec.Name()]
+		if !ok {
+			var err error
+			bytes, err = c.PacketToBytes(codec, packet)
+			if err != nil {
+				fmt.Println("❌ Encode Error:", err)
+				return
 			}
+			encoded[codec.Name()] = bytes
 		}
-		c.games = newGames
-		c.gamesMutex.Unlock()
-
-		return
+		client.Send(bytes)
 	}
 }
 
-func (c *NetService) SendPacket(connection *websocket.Conn, packet any) error {
-	bytes, err := c.PacketToBytes(packet)
-	if err != nil {
-		return err
+func (c *NetService) connectionCodec(con *websocket.Conn) Codec {
+	if client := c.client(con); client != nil {
+		return client.Codec()
 	}
-	return connection.WriteMessage(websocket.BinaryMessage, bytes)
+	return JSONCodec
 }
 
-func (c *NetService) PacketToBytes(packet any) ([]byte, error) {
+func (c *NetService) PacketToBytes(codec Codec, packet any) ([]byte, error) {
 	packetId, err := c.packettoPacketId(packet)
 	if err != nil {
 		return nil, err
 	}
 
-	bytes, err := json.Marshal(packet)
+	bytes, err := codec.Marshal(packet)
 	if err != nil {
 		return nil, err
 	}
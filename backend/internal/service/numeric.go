@@ -0,0 +1,96 @@
+package service
+
+import (
+	"math"
+
+	"CorrectQuiz.com/quiz/internal/entity"
+)
+
+const numericHistogramBuckets = 10
+
+type HistogramBucket struct {
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Count int     `json:"count"`
+}
+
+func snapNumericAnswer(options entity.NumericOptions, value float64) (float64, bool) {
+	if math.IsNaN(value) || math.IsInf(value, 0) || value < options.Min || value > options.Max {
+		return 0, false
+	}
+	if options.Step > 0 {
+		value = options.Min + math.Round((value-options.Min)/options.Step)*options.Step
+		value = math.Min(value, options.Max)
+	}
+	return value, true
+}
+
+func gradeNumeric(question entity.QuizQuestion, value *float64) float64 {
+	if value == nil {
+		return 0
+	}
+	options := question.Numeric
+	snapped, ok := snapNumericAnswer(options, *value)
+	if !ok {
+		return 0
+	}
+
+	distance := math.Abs(snapped - options.Correct)
+	const epsilon = 1e-9
+
+	switch question.ScoringMode {
+	case entity.ScoringTolerance:
+		if distance <= options.Tolerance+epsilon {
+			return 1
+		}
+		return 0
+	case entity.ScoringLinear:
+		span := options.Tolerance
+		if span <= 0 {
+			span = options.Max - options.Min
+		}
+		if span <= 0 {
+			return 0
+		}
+		return math.Max(0, 1-distance/span)
+	default:
+		if distance <= epsilon {
+			return 1
+		}
+		return 0
+	}
+}
+
+func numericHistogram(options entity.NumericOptions, values []float64) []HistogramBucket {
+	span := options.Max - options.Min
+	if span <= 0 {
+		return []HistogramBucket{}
+	}
+
+	bucketCount := numericHistogramBuckets
+	if options.Step > 0 {
+		steps := int(math.Round(span/options.Step)) + 1
+		if steps < bucketCount {
+			bucketCount = steps
+		}
+	}
+
+	width := span / float64(bucketCount)
+	buckets := make([]HistogramBucket, bucketCount)
+	for i := range buckets {
+		buckets[i].Min = options.Min + float64(i)*width
+		buckets[i].Max = options.Min + float64(i+1)*width
+	}
+
+	for _, value := range values {
+		i := int((value - options.Min) / width)
+		if i >= bucketCount {
+			i = bucketCount - 1
+		}
+		if i < 0 {
+			continue
+		}
+		buckets[i].Count++
+	}
+	return buckets
+}
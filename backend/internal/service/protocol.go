@@ -0,0 +1,103 @@
+package service
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/gofiber/contrib/websocket"
+)
+
+const (
+	ProtocolVersion    = 1
+	MinProtocolVersion = 1
+	helloPacketId      = 29
+)
+
+const (
+	CapabilityTeams        = "teams"
+	CapabilityAutoPilot    = "autopilot"
+	CapabilitySelfPaced    = "self_paced"
+	CapabilityHostControls = "host_controls"
+	CapabilityShuffle      = "shuffle"
+	CapabilityErrors       = "errors"
+	CapabilityMsgPack      = "msgpack"
+)
+
+var serverCapabilities = []string{
+	CapabilityTeams,
+	CapabilityAutoPilot,
+	CapabilitySelfPaced,
+	CapabilityHostControls,
+	CapabilityShuffle,
+	CapabilityErrors,
+	CapabilityMsgPack,
+}
+
+type HelloPacket struct {
+	Version      int      `json:"version"`
+	Capabilities []string `json:"capabilities"`
+}
+
+type ProtocolRejectPacket struct {
+	Reason     string `json:"reason"`
+	MinVersion int    `json:"minVersion"`
+	MaxVersion int    `json:"maxVersion"`
+}
+
+func negotiateCapabilities(requested []string) []string {
+	supported := make(map[string]bool, len(serverCapabilities))
+	for _, capability := range serverCapabilities {
+		supported[capability] = true
+	}
+
+	negotiated := []string{}
+	for _, capability := range requested {
+		if supported[capability] {
+			negotiated = append(negotiated, capability)
+			delete(supported, capability)
+		}
+	}
+	return negotiated
+}
+
+func (c *NetService) rejectProtocol(con *websocket.Conn, reason string) {
+	log.Printf("🚫 Rejecting client %s: %s", con.RemoteAddr(), reason)
+	c.SendPacket(con, ProtocolRejectPacket{
+		Reason:     reason,
+		MinVersion: MinProtocolVersion,
+		MaxVersion: ProtocolVersion,
+	})
+	c.CloseConnection(con)
+}
+
+func (c *NetService) onHello(con *websocket.Conn, hello *HelloPacket) {
+	client := c.client(con)
+	if client == nil {
+		return
+	}
+
+	if client.negotiated {
+		c.sendError(con, ErrorCodeInvalidPayload, "Handshake already completed")
+		return
+	}
+
+	if hello.Version < MinProtocolVersion || hello.Version > ProtocolVersion {
+		c.rejectProtocol(con, fmt.Sprintf("Unsupported protocol version %d", hello.Version))
+		return
+	}
+
+	client.negotiated = true
+	client.version = hello.Version
+	client.capabilities = negotiateCapabilities(hello.Capabilities)
+
+	c.SendPacket(con, HelloPacket{
+		Version:      client.version,
+		Capabilities: client.capabilities,
+	})
+	client.setCodec(negotiateCodec(client.capabilities))
+}
+
+func (c *NetService) handshakeCompleted(con *websocket.Conn) bool {
+	client := c.client(con)
+	return client != nil && client.negotiated
+}
@@ -45,12 +45,13 @@ func (s *QuizService) GetQuizById(id uint) (*entity.Quiz, error) {
 	return s.quizCollection.GetQuizById(id)
 }
 
-func (s *QuizService) UpdateQuiz(id uint, userID uint64, name string, questions []entity.QuizQuestion) error {
+func (s *QuizService) UpdateQuiz(id uint, userID uint64, name string, settings entity.QuizSettings, questions []entity.QuizQuestion) error {
 	quizToUpdate := entity.Quiz{
-		ID:        id,
-		Name:      name,
-		Questions: questions,
-		UserID:    userID,
+		ID:           id,
+		Name:         name,
+		Questions:    questions,
+		UserID:       userID,
+		QuizSettings: settings,
 	}
 
 	for i := range quizToUpdate.Questions {
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DefaultCodeAlphabet = "0123456789"
+	DefaultCodeLength   = 6
+	DefaultCodeCooldown = 30 * time.Minute
+	maxCodeAttempts     = 1000
+)
+
+var (
+	ErrCodeSpaceExhausted = errors.New("no free game code available")
+	ErrCodeOptions        = errors.New("game code alphabet must have at least two characters and length must be positive")
+)
+
+type CodeOptions struct {
+	Alphabet string
+	Length   int
+	Cooldown time.Duration
+}
+
+func DefaultCodeOptions() CodeOptions {
+	return CodeOptions{
+		Alphabet: DefaultCodeAlphabet,
+		Length:   DefaultCodeLength,
+		Cooldown: DefaultCodeCooldown,
+	}
+}
+
+type GameRegistry struct {
+	mutex         sync.RWMutex
+	options       CodeOptions
+	alphabet      []rune
+	byCode        map[string]*Game
+	byId          map[uuid.UUID]*Game
+	recentlyEnded map[string]time.Time
+}
+
+func NewGameRegistry(options CodeOptions) (*GameRegistry, error) {
+	alphabet := []rune(options.Alphabet)
+	if len(alphabet) < 2 || options.Length <= 0 {
+		return nil, ErrCodeOptions
+	}
+	return &GameRegistry{
+		options:       options,
+		alphabet:      alphabet,
+		byCode:        make(map[string]*Game),
+		byId:          make(map[uuid.UUID]*Game),
+		recentlyEnded: make(map[string]time.Time),
+	}, nil
+}
+
+func (r *GameRegistry) generateCode() string {
+	var code strings.Builder
+	for i := 0; i < r.options.Length; i++ {
+		code.WriteRune(r.alphabet[rand.Intn(len(r.alphabet))])
+	}
+	return code.String()
+}
+
+func (r *GameRegistry) pruneCooldownsLocked(now time.Time) {
+	for code, endedAt := range r.recentlyEnded {
+		if now.Sub(endedAt) >= r.options.Cooldown {
+			delete(r.recentlyEnded, code)
+		}
+	}
+}
+
+func (r *GameRegistry) codeAvailableLocked(code string) bool {
+	if _, live := r.byCode[code]; live {
+		return false
+	}
+	_, coolingDown := r.recentlyEnded[code]
+	return !coolingDown
+}
+
+func (r *GameRegistry) Register(game *Game) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.pruneCooldownsLocked(time.Now())
+
+	for attempt := 0; attempt < maxCodeAttempts; attempt++ {
+		code := r.generateCode()
+		if !r.codeAvailableLocked(code) {
+			continue
+		}
+		game.Code = code
+		game.touch()
+		r.byCode[code] = game
+		r.byId[game.Id] = game
+		return nil
+	}
+	return ErrCodeSpaceExhausted
+}
+
+func (r *GameRegistry) Remove(game *Game) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.byId[game.Id] != game {
+		return false
+	}
+	delete(r.byId, game.Id)
+	delete(r.byCode, game.Code)
+	r.recentlyEnded[game.Code] = time.Now()
+	return true
+}
+
+func (r *GameRegistry) ByCode(code string) *Game {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.byCode[code]
+}
+
+func (r *GameRegistry) ById(id uuid.UUID) *Game {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.byId[id]
+}
+
+func (r *GameRegistry) All() []*Game {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	games := make([]*Game, 0, len(r.byId))
+	for _, game := range r.byId {
+		games = append(games, game)
+	}
+	return games
+}
+
+func (r *GameRegistry) Count() int {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return len(r.byId)
+}
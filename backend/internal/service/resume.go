@@ -0,0 +1,65 @@
+package service
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const resumeTokenLifetime = 12 * time.Hour
+
+var resumeTokenSecret = newResumeTokenSecret()
+
+func newResumeTokenSecret() []byte {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		panic(fmt.Sprintf("failed to generate resume token secret: %v", err))
+	}
+	return secret
+}
+
+type resumeClaims struct {
+	GameId   uuid.UUID `json:"game_id"`
+	PlayerId uuid.UUID `json:"player_id"`
+	Host     bool      `json:"host,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func signResumeToken(gameId uuid.UUID, playerId uuid.UUID) (string, error) {
+	return signResumeClaims(resumeClaims{
+		GameId:   gameId,
+		PlayerId: playerId,
+	})
+}
+
+func signHostResumeToken(gameId uuid.UUID) (string, error) {
+	return signResumeClaims(resumeClaims{
+		GameId: gameId,
+		Host:   true,
+	})
+}
+
+func signResumeClaims(claims resumeClaims) (string, error) {
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(resumeTokenLifetime)),
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(resumeTokenSecret)
+}
+
+func parseResumeToken(tokenString string) (*resumeClaims, error) {
+	claims := &resumeClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return resumeTokenSecret, nil
+	})
+	if err != nil || !token.Valid {
+		return nil, errors.New("invalid resume token")
+	}
+	return claims, nil
+}
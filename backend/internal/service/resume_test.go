@@ -0,0 +1,359 @@
+package service
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"CorrectQuiz.com/quiz/internal/entity"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+func registeredGame(t *testing.T, netService *NetService) (*Game, *Client) {
+	t.Helper()
+	hostConnection, hostClient := captureConnection(netService)
+	quiz := entity.Quiz{
+		Name:      "resume test",
+		Questions: []entity.QuizQuestion{leakTestQuestion(entity.QuestionTypeSingle)},
+	}
+	game := newGame(quiz, hostConnection, netService)
+	if err := netService.games.Register(&game); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(game.cancelFunc)
+	return &game, hostClient
+}
+
+func packetIds(packets [][]byte) []byte {
+	ids := make([]byte, 0, len(packets))
+	for _, packet := range packets {
+		ids = append(ids, packet[0])
+	}
+	return ids
+}
+
+func disconnectedPlayer(t *testing.T, netService *NetService, game *Game) (*Player, string) {
+	t.Helper()
+	connection, _ := captureConnection(netService)
+	player := game.OnPlayerJoin("resumer", connection)
+	token, err := signResumeToken(game.Id, player.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	game.OnPlayerDisconnect(player)
+	return player, token
+}
+
+func TestPlayerDisconnectHoldsSeat(t *testing.T) {
+	netService, err := Net(nil, nil, DefaultCodeOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	game, _ := registeredGame(t, netService)
+	player, token := disconnectedPlayer(t, netService, game)
+
+	if game.GetPlayer(player.Id) == nil {
+		t.Fatal("disconnected player was removed before the grace period")
+	}
+	if !player.Disconnected || player.Connection != nil {
+		t.Errorf("player not marked disconnected: disconnected=%v connection=%v", player.Disconnected, player.Connection)
+	}
+	if !netService.IsNameTakenInGame(game.Code, player.Name) {
+		t.Error("name of a disconnected player was released before the grace period")
+	}
+	if !netService.CanResume(game.Code, token) {
+		t.Error("resume token was not accepted while the seat is held")
+	}
+	if netService.CanResume("000000", token) {
+		t.Error("resume token was accepted for a different game code")
+	}
+}
+
+func TestExpireDisconnectedPlayerRemovesSeat(t *testing.T) {
+	netService, err := Net(nil, nil, DefaultCodeOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	game, hostClient := registeredGame(t, netService)
+	player, token := disconnectedPlayer(t, netService, game)
+	drainPackets(hostClient)
+
+	game.expireDisconnectedPlayer(player.Id, player.disconnectedAt)
+
+	if game.GetPlayer(player.Id) != nil {
+		t.Fatal("player was not removed after the grace period")
+	}
+	if ids := packetIds(drainPackets(hostClient)); !slices.Contains(ids, 17) {
+		t.Errorf("host was not sent PlayerLeavePacket, got packet ids %v", ids)
+	}
+	if netService.CanResume(game.Code, token) {
+		t.Error("resume token was accepted after the seat expired")
+	}
+
+	connection, _ := captureConnection(netService)
+	if netService.resumePlayer(connection, token) {
+		t.Error("expired player was resumed")
+	}
+}
+
+func TestExpireDisconnectedPlayerIgnoresResumedPlayer(t *testing.T) {
+	netService, err := Net(nil, nil, DefaultCodeOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	game, _ := registeredGame(t, netService)
+	player, token := disconnectedPlayer(t, netService, game)
+	firstDisconnect := player.disconnectedAt
+
+	connection, _ := captureConnection(netService)
+	if !netService.resumePlayer(connection, token) {
+		t.Fatal("valid resume token was rejected")
+	}
+	game.expireDisconnectedPlayer(player.Id, firstDisconnect)
+	if game.GetPlayer(player.Id) == nil {
+		t.Fatal("resumed player was removed by the timer of an earlier disconnect")
+	}
+
+	game.OnPlayerDisconnect(player)
+	game.expireDisconnectedPlayer(player.Id, firstDisconnect)
+	if game.GetPlayer(player.Id) == nil {
+		t.Fatal("stale timer removed a player who disconnected again later")
+	}
+}
+
+func TestOnPlayerResumeRestoresPlayer(t *testing.T) {
+	netService, err := Net(nil, nil, DefaultCodeOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	game, _ := registeredGame(t, netService)
+	game.Start()
+	player, token := disconnectedPlayer(t, netService, game)
+	player.Points = 250
+
+	connection, client := captureConnection(netService)
+	if !netService.resumePlayer(connection, token) {
+		t.Fatal("valid resume token was rejected")
+	}
+
+	if player.Disconnected || player.Connection != connection {
+		t.Errorf("player not reattached: disconnected=%v", player.Disconnected)
+	}
+	ids := packetIds(drainPackets(client))
+	for _, want := range []byte{3, 4, 18, 11, 2, 6} {
+		if !slices.Contains(ids, want) {
+			t.Errorf("resumed player was not sent packet %d, got %v", want, ids)
+		}
+	}
+	if found, _ := netService.GetGameByPlayer(connection); found != game {
+		t.Error("new connection is not mapped to the game")
+	}
+}
+
+func TestResumeRejectsInvalidTokens(t *testing.T) {
+	netService, err := Net(nil, nil, DefaultCodeOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	game, _ := registeredGame(t, netService)
+	player, _ := disconnectedPlayer(t, netService, game)
+
+	hostToken, _ := signHostResumeToken(game.Id)
+	otherGameToken, _ := signResumeToken(uuid.New(), player.Id)
+	unknownPlayerToken, _ := signResumeToken(game.Id, uuid.New())
+	expiredToken, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, resumeClaims{
+		GameId:   game.Id,
+		PlayerId: player.Id,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+		},
+	}).SignedString(resumeTokenSecret)
+	forgedToken, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, resumeClaims{
+		GameId:   game.Id,
+		PlayerId: player.Id,
+	}).SignedString([]byte("not the server secret"))
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"garbage", "not-a-token"},
+		{"host token", hostToken},
+		{"other game", otherGameToken},
+		{"unknown player", unknownPlayerToken},
+		{"expired", expiredToken},
+		{"wrong secret", forgedToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if netService.CanResume(game.Code, tt.token) {
+				t.Error("CanResume accepted an invalid token")
+			}
+			connection, _ := captureConnection(netService)
+			if netService.resumePlayer(connection, tt.token) {
+				t.Error("resumePlayer accepted an invalid token")
+			}
+			if !player.Disconnected || player.Connection != nil {
+				t.Error("invalid token reattached the player")
+			}
+		})
+	}
+}
+
+func hostResumeConnection(t *testing.T, netService *NetService, game *Game) (*Client, string) {
+	t.Helper()
+	game.HostUserID = 7
+	_, client := captureConnection(netService)
+	client.userID = 7
+	token, err := signHostResumeToken(game.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return client, token
+}
+
+func TestOnHostResumeSendsSnapshot(t *testing.T) {
+	netService, err := Net(nil, nil, DefaultCodeOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	game, _ := registeredGame(t, netService)
+	playerConnection, playerClient := captureConnection(netService)
+	player := game.OnPlayerJoin("stays", playerConnection)
+	game.Start()
+	player.Points = 120
+	game.OnHostDisconnect()
+
+	if ids := packetIds(drainPackets(playerClient)); !slices.Contains(ids, 19) {
+		t.Errorf("players were not told the game paused, got %v", ids)
+	}
+
+	client, token := hostResumeConnection(t, netService, game)
+	if !netService.resumeHost(client.connection, token) {
+		t.Fatal("valid host resume token was rejected")
+	}
+	if game.Host != client.connection || game.hostAway {
+		t.Fatal("host connection was not reattached")
+	}
+
+	var snapshot *GameSnapshotPacket
+	packets := drainPackets(client)
+	for _, packet := range packets {
+		if packet[0] != 20 {
+			continue
+		}
+		snapshot = &GameSnapshotPacket{}
+		if err := JSONCodec.Unmarshal(packet[1:], snapshot); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if ids := packetIds(packets); !slices.Contains(ids, 1) || !slices.Contains(ids, 18) {
+		t.Errorf("host was not sent the game code and a new resume token, got %v", ids)
+	}
+	if snapshot == nil {
+		t.Fatalf("host was not sent a snapshot, got %v", packetIds(packets))
+	}
+	if snapshot.Code != game.Code || snapshot.State != PlayState || snapshot.QuestionIndex != 0 {
+		t.Errorf("snapshot = code %q state %d question %d, want %q %d 0", snapshot.Code, snapshot.State, snapshot.QuestionIndex, game.Code, PlayState)
+	}
+	if len(snapshot.Players) != 1 || snapshot.Players[0].Id != player.Id {
+		t.Errorf("snapshot players = %+v, want the one joined player", snapshot.Players)
+	}
+	if snapshot.Question == nil || snapshot.Question.Name != game.Quiz.Questions[0].Name {
+		t.Errorf("snapshot question = %+v, want the current question", snapshot.Question)
+	}
+	if len(snapshot.Leaderboard) != 1 || snapshot.Leaderboard[0].Points != 120 {
+		t.Errorf("snapshot leaderboard = %+v, want the player with 120 points", snapshot.Leaderboard)
+	}
+	if snapshot.Paused {
+		t.Error("snapshot reports the game paused after the host returned")
+	}
+	if ids := packetIds(drainPackets(playerClient)); !slices.Contains(ids, 19) {
+		t.Errorf("players were not told the game resumed, got %v", ids)
+	}
+}
+
+func TestOnHostResumeRejectsOtherUsers(t *testing.T) {
+	netService, err := Net(nil, nil, DefaultCodeOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	game, _ := registeredGame(t, netService)
+	game.OnHostDisconnect()
+
+	client, token := hostResumeConnection(t, netService, game)
+	client.userID = 8
+	if netService.resumeHost(client.connection, token) {
+		t.Error("another user resumed the host seat")
+	}
+
+	playerToken, _ := signResumeToken(game.Id, uuid.New())
+	client.userID = 7
+	if netService.resumeHost(client.connection, playerToken) {
+		t.Error("a player token resumed the host seat")
+	}
+}
+
+func waitForGameRemoval(netService *NetService, game *Game, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if netService.GetGameById(game.Id) == nil {
+			return true
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return false
+}
+
+func TestHostDisconnectEndsGameOnlyAfterGrace(t *testing.T) {
+	netService, err := Net(nil, nil, DefaultCodeOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	game, _ := registeredGame(t, netService)
+	game.hostReconnectGrace = 50 * time.Millisecond
+	playerConnection, playerClient := captureConnection(netService)
+	game.OnPlayerJoin("waiting", playerConnection)
+	drainPackets(playerClient)
+
+	game.OnHostDisconnect()
+	if netService.GetGameById(game.Id) == nil {
+		t.Fatal("game ended as soon as the host disconnected")
+	}
+	if !waitForGameRemoval(netService, game, time.Second) {
+		t.Fatal("game was not ended after the host grace period")
+	}
+
+	var ended bool
+	for _, packet := range drainPackets(playerClient) {
+		var state ChangeGameStatePacket
+		if packet[0] == 3 && JSONCodec.Unmarshal(packet[1:], &state) == nil && state.State == GameEndedState {
+			ended = true
+		}
+	}
+	if !ended {
+		t.Error("players were not told the game ended")
+	}
+}
+
+func TestHostResumeWithinGraceKeepsGame(t *testing.T) {
+	netService, err := Net(nil, nil, DefaultCodeOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	game, _ := registeredGame(t, netService)
+	game.hostReconnectGrace = 50 * time.Millisecond
+
+	game.OnHostDisconnect()
+	client, token := hostResumeConnection(t, netService, game)
+	if !netService.resumeHost(client.connection, token) {
+		t.Fatal("valid host resume token was rejected")
+	}
+
+	if waitForGameRemoval(netService, game, 150*time.Millisecond) {
+		t.Fatal("game was ended even though the host returned within the grace period")
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"math"
+
+	"CorrectQuiz.com/quiz/internal/entity"
+)
+
+type ScoreInput struct {
+	Credit        float64
+	Rank          int
+	TimeRemaining int
+	TimeLimit     int
+	Streak        int
+}
+
+type ScoreResult struct {
+	Points      int
+	StreakBonus int
+	ResetStreak bool
+}
+
+type ScoringStrategy interface {
+	Score(input ScoreInput) ScoreResult
+}
+
+func scoringStrategyFor(name entity.ScoringStrategy) ScoringStrategy {
+	switch name {
+	case entity.StrategyTimeDecay:
+		return TimeDecayScoring{}
+	case entity.StrategyFlat:
+		return FlatScoring{}
+	case entity.StrategyExam:
+		return ExamScoring{}
+	default:
+		return RankBonusScoring{}
+	}
+}
+
+type RankBonusScoring struct{}
+
+func (RankBonusScoring) Score(input ScoreInput) ScoreResult {
+	const basePointsForCorrect = 100.0
+
+	if input.Credit < 1 {
+		return ScoreResult{
+			Points: int(math.Round(basePointsForCorrect * math.Max(input.Credit, 0))),
+		}
+	}
+
+	rankedScores := []int{26, 24, 22}
+	baseCorrectScore := 20
+
+	result := ScoreResult{
+		Points: int(basePointsForCorrect) + baseCorrectScore,
+	}
+	if input.Rank >= 0 && input.Rank < len(rankedScores) {
+		result.Points = int(basePointsForCorrect) + rankedScores[input.Rank]
+	}
+
+	if input.Streak == 2 {
+		result.StreakBonus = 10
+	} else if input.Streak == 3 {
+		result.StreakBonus = 20
+		result.ResetStreak = true
+	}
+	return result
+}
+
+type TimeDecayScoring struct{}
+
+func (TimeDecayScoring) Score(input ScoreInput) ScoreResult {
+	const maxPoints = 1000.0
+	const streakStep = 100
+	const maxStreakBonus = 500
+
+	if input.Credit <= 0 {
+		return ScoreResult{}
+	}
+
+	timeLimit := float64(input.TimeLimit)
+	if timeLimit <= 0 {
+		timeLimit = 60.0
+	}
+	elapsed := math.Min(math.Max(timeLimit-float64(input.TimeRemaining), 0), timeLimit)
+
+	result := ScoreResult{
+		Points: int(math.Round(maxPoints * input.Credit * (1 - (elapsed/timeLimit)/2))),
+	}
+	if input.Credit >= 1 && input.Streak >= 2 {
+		result.StreakBonus = min((input.Streak-1)*streakStep, maxStreakBonus)
+	}
+	return result
+}
+
+type FlatScoring struct{}
+
+func (FlatScoring) Score(input ScoreInput) ScoreResult {
+	if input.Credit >= 1 {
+		return ScoreResult{Points: 100}
+	}
+	return ScoreResult{}
+}
+
+type ExamScoring struct{}
+
+func (ExamScoring) Score(input ScoreInput) ScoreResult {
+	return ScoreResult{
+		Points: int(math.Round(100 * math.Max(input.Credit, 0))),
+	}
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"testing"
+
+	"CorrectQuiz.com/quiz/internal/entity"
+	"github.com/google/uuid"
+)
+
+func TestScoringStrategies(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy entity.ScoringStrategy
+		input    ScoreInput
+		want     ScoreResult
+	}{
+		{"rank_bonus first correct", entity.StrategyRankBonus, ScoreInput{Credit: 1, Rank: 0, TimeRemaining: 10, TimeLimit: 20}, ScoreResult{Points: 126}},
+		{"rank_bonus third correct", entity.StrategyRankBonus, ScoreInput{Credit: 1, Rank: 2, TimeRemaining: 10, TimeLimit: 20}, ScoreResult{Points: 122}},
+		{"rank_bonus unranked correct", entity.StrategyRankBonus, ScoreInput{Credit: 1, Rank: -1, TimeRemaining: 10, TimeLimit: 20}, ScoreResult{Points: 120}},
+		{"rank_bonus incorrect", entity.StrategyRankBonus, ScoreInput{Credit: 0, Rank: -1, TimeRemaining: 10, TimeLimit: 20}, ScoreResult{}},
+		{"rank_bonus partial credit", entity.StrategyRankBonus, ScoreInput{Credit: 0.5, Rank: -1, TimeRemaining: 10, TimeLimit: 20}, ScoreResult{Points: 50}},
+		{"rank_bonus negative credit", entity.StrategyRankBonus, ScoreInput{Credit: -0.5, Rank: -1}, ScoreResult{}},
+		{"rank_bonus zero time remaining", entity.StrategyRankBonus, ScoreInput{Credit: 1, Rank: 1, TimeRemaining: 0, TimeLimit: 20}, ScoreResult{Points: 124}},
+		{"rank_bonus streak of two", entity.StrategyRankBonus, ScoreInput{Credit: 1, Rank: -1, Streak: 2}, ScoreResult{Points: 120, StreakBonus: 10}},
+		{"rank_bonus streak of three resets", entity.StrategyRankBonus, ScoreInput{Credit: 1, Rank: -1, Streak: 3}, ScoreResult{Points: 120, StreakBonus: 20, ResetStreak: true}},
+
+		{"time_decay instant correct", entity.StrategyTimeDecay, ScoreInput{Credit: 1, TimeRemaining: 20, TimeLimit: 20}, ScoreResult{Points: 1000}},
+		{"time_decay half time correct", entity.StrategyTimeDecay, ScoreInput{Credit: 1, TimeRemaining: 10, TimeLimit: 20}, ScoreResult{Points: 750}},
+		{"time_decay zero time remaining", entity.StrategyTimeDecay, ScoreInput{Credit: 1, TimeRemaining: 0, TimeLimit: 20}, ScoreResult{Points: 500}},
+		{"time_decay incorrect", entity.StrategyTimeDecay, ScoreInput{Credit: 0, TimeRemaining: 20, TimeLimit: 20}, ScoreResult{}},
+		{"time_decay partial credit", entity.StrategyTimeDecay, ScoreInput{Credit: 0.5, TimeRemaining: 20, TimeLimit: 20, Streak: 4}, ScoreResult{Points: 500}},
+		{"time_decay default time limit", entity.StrategyTimeDecay, ScoreInput{Credit: 1, TimeRemaining: 30, TimeLimit: 0}, ScoreResult{Points: 750}},
+		{"time_decay streak bonus", entity.StrategyTimeDecay, ScoreInput{Credit: 1, TimeRemaining: 20, TimeLimit: 20, Streak: 3}, ScoreResult{Points: 1000, StreakBonus: 200}},
+		{"time_decay streak bonus capped", entity.StrategyTimeDecay, ScoreInput{Credit: 1, TimeRemaining: 20, TimeLimit: 20, Streak: 20}, ScoreResult{Points: 1000, StreakBonus: 500}},
+
+		{"flat correct", entity.StrategyFlat, ScoreInput{Credit: 1, Rank: 0, TimeRemaining: 20, TimeLimit: 20, Streak: 3}, ScoreResult{Points: 100}},
+		{"flat incorrect", entity.StrategyFlat, ScoreInput{Credit: 0, Rank: -1}, ScoreResult{}},
+		{"flat partial credit", entity.StrategyFlat, ScoreInput{Credit: 0.75, Rank: -1}, ScoreResult{}},
+		{"flat zero time remaining", entity.StrategyFlat, ScoreInput{Credit: 1, Rank: -1, TimeRemaining: 0, TimeLimit: 20}, ScoreResult{Points: 100}},
+
+		{"exam correct", entity.StrategyExam, ScoreInput{Credit: 1, Rank: 0, Streak: 3}, ScoreResult{Points: 100}},
+		{"exam incorrect", entity.StrategyExam, ScoreInput{Credit: 0, Rank: -1}, ScoreResult{}},
+		{"exam partial credit", entity.StrategyExam, ScoreInput{Credit: 0.25, Rank: -1}, ScoreResult{Points: 25}},
+		{"exam negative credit", entity.StrategyExam, ScoreInput{Credit: -1, Rank: -1}, ScoreResult{}},
+		{"exam zero time remaining", entity.StrategyExam, ScoreInput{Credit: 1, Rank: -1, TimeRemaining: 0, TimeLimit: 20}, ScoreResult{Points: 100}},
+
+		{"unknown strategy falls back to rank_bonus", entity.ScoringStrategy("unknown"), ScoreInput{Credit: 1, Rank: 0}, ScoreResult{Points: 126}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scoringStrategyFor(tt.strategy).Score(tt.input)
+			if got != tt.want {
+				t.Errorf("Score(%+v) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScorePlayerAppliesMultiplier(t *testing.T) {
+	double := 2.0
+	tests := []struct {
+		name                 string
+		strategy             entity.ScoringStrategy
+		questionMultiplier   *float64
+		bonusRoundMultiplier float64
+		questionIndex        int
+		credit               float64
+		timeRemaining        int
+		want                 int
+	}{
+		{"flat without multiplier", entity.StrategyFlat, nil, 0, 0, 1, 10, 100},
+		{"flat with question multiplier", entity.StrategyFlat, &double, 0, 0, 1, 10, 200},
+		{"exam partial credit with multiplier", entity.StrategyExam, &double, 0, 0, 0.5, 10, 100},
+		{"flat incorrect with multiplier", entity.StrategyFlat, &double, 0, 0, 0, 10, 0},
+		{"time_decay zero time with multiplier", entity.StrategyTimeDecay, &double, 0, 0, 1, 0, 1000},
+		{"bonus round on last question", entity.StrategyFlat, nil, 3, 1, 1, 10, 300},
+		{"bonus round ignored before last question", entity.StrategyFlat, nil, 3, 0, 1, 10, 100},
+		{"bonus round stacks with question multiplier", entity.StrategyExam, &double, 3, 1, 1, 10, 600},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			questions := []entity.QuizQuestion{
+				{Type: entity.QuestionTypeSingle, Time: 20, PointsMultiplier: tt.questionMultiplier},
+				{Type: entity.QuestionTypeSingle, Time: 20, PointsMultiplier: tt.questionMultiplier},
+			}
+			game := Game{
+				Quiz: entity.Quiz{
+					Questions: questions,
+					QuizSettings: entity.QuizSettings{
+						ScoringStrategy:      tt.strategy,
+						BonusRoundMultiplier: tt.bonusRoundMultiplier,
+					},
+				},
+				correctAnswerCounts: make(map[uuid.UUID]int),
+			}
+			player := &Player{
+				Id:                  uuid.New(),
+				Answered:            true,
+				AnswerTimeRemaining: tt.timeRemaining,
+			}
+
+			record, _, reveal := game.scorePlayerLocked(player, tt.questionIndex, tt.credit, -1)
+			if record.points != tt.want || reveal.Points != tt.want {
+				t.Errorf("awarded %d points (total %d), want %d", record.points, reveal.Points, tt.want)
+			}
+		})
+	}
+}
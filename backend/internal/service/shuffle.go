@@ -0,0 +1,152 @@
+package service
+
+import (
+	"math/rand"
+	"slices"
+
+	"CorrectQuiz.com/quiz/internal/entity"
+)
+
+func (g *Game) shuffleQuestions() {
+	if !g.Quiz.ShuffleQuestions {
+		return
+	}
+
+	questions := append([]entity.QuizQuestion{}, g.Quiz.Questions...)
+	rand.Shuffle(len(questions), func(i, j int) {
+		questions[i], questions[j] = questions[j], questions[i]
+	})
+	g.Quiz.Questions = questions
+}
+
+func hasShufflableChoices(question entity.QuizQuestion) bool {
+	switch question.Type {
+	case entity.QuestionTypeText, entity.QuestionTypeNumeric, entity.QuestionTypeWordCloud:
+		return false
+	}
+	return len(question.Choices) > 1
+}
+
+func (g *Game) shufflesChoices(question entity.QuizQuestion) bool {
+	if question.Type == entity.QuestionTypeOrdering {
+		return len(question.Choices) > 1
+	}
+	return g.Quiz.ShuffleChoices && hasShufflableChoices(question)
+}
+
+func (g *Game) choiceOrderLocked(player *Player, questionIndex int) []int {
+	question := g.Quiz.Questions[questionIndex]
+	if !g.shufflesChoices(question) {
+		return nil
+	}
+
+	if order, ok := player.choiceOrders[questionIndex]; ok {
+		return order
+	}
+
+	order := rand.Perm(len(question.Choices))
+	if question.Type == entity.QuestionTypeOrdering {
+		correct := correctChoiceOrder(question)
+		for slices.Equal(order, correct) {
+			order = rand.Perm(len(question.Choices))
+		}
+	}
+	if player.choiceOrders == nil {
+		player.choiceOrders = make(map[int][]int)
+	}
+	player.choiceOrders[questionIndex] = order
+	return order
+}
+
+func (g *Game) playerQuestionShowPacket(player *Player, questionIndex int) QuestionShowPacket {
+	packet := g.questionShowPacket(questionIndex)
+
+	g.playersMutex.Lock()
+	order := g.choiceOrderLocked(player, questionIndex)
+	g.playersMutex.Unlock()
+
+	if order == nil {
+		return packet
+	}
+
+	choices := make([]PlayerChoice, len(order))
+	for displayIndex, canonicalIndex := range order {
+		choices[displayIndex] = packet.Question.Choices[canonicalIndex]
+	}
+	packet.Question.Choices = choices
+	return packet
+}
+
+func (g *Game) sendQuestionShow(questionIndex int) {
+	if !g.shufflesChoices(g.Quiz.Questions[questionIndex]) {
+		g.BroadcastPacket(g.questionShowPacket(questionIndex), false)
+		g.playersMutex.RLock()
+		hostConnection := g.Host
+		g.playersMutex.RUnlock()
+		g.netService.SendPacket(hostConnection, g.hostQuestionShowPacket(questionIndex))
+		return
+	}
+
+	g.playersMutex.RLock()
+	players := append([]*Player{}, g.Players...)
+	hostConnection := g.Host
+	g.playersMutex.RUnlock()
+
+	for _, player := range players {
+		if player.Connection != nil {
+			g.netService.SendPacket(player.Connection, g.playerQuestionShowPacket(player, questionIndex))
+		}
+	}
+	g.netService.SendPacket(hostConnection, g.hostQuestionShowPacket(questionIndex))
+}
+
+func toCanonicalChoice(order []int, displayIndex int) int {
+	if displayIndex < 0 || displayIndex >= len(order) {
+		return -1
+	}
+	return order[displayIndex]
+}
+
+func (g *Game) canonicalAnswerLocked(player *Player, questionIndex int, answer PlayerAnswer) PlayerAnswer {
+	if questionIndex < 0 || questionIndex >= len(g.Quiz.Questions) {
+		return answer
+	}
+	order := g.choiceOrderLocked(player, questionIndex)
+	if order == nil {
+		return answer
+	}
+
+	canonical := answer
+	canonical.Choice = toCanonicalChoice(order, answer.Choice)
+	if answer.Choices != nil {
+		canonical.Choices = make([]int, len(answer.Choices))
+		for i, choice := range answer.Choices {
+			canonical.Choices[i] = toCanonicalChoice(order, choice)
+		}
+	}
+	if answer.Order != nil {
+		canonical.Order = make([]int, len(answer.Order))
+		for i, choice := range answer.Order {
+			canonical.Order[i] = toCanonicalChoice(order, choice)
+		}
+	}
+	return canonical
+}
+
+func (g *Game) playerViewIndexesLocked(player *Player, questionIndex int, indexes []int) []int {
+	order := g.choiceOrderLocked(player, questionIndex)
+	if order == nil || indexes == nil {
+		return indexes
+	}
+
+	displayIndexes := make(map[int]int, len(order))
+	for displayIndex, canonicalIndex := range order {
+		displayIndexes[canonicalIndex] = displayIndex
+	}
+
+	view := make([]int, len(indexes))
+	for i, index := range indexes {
+		view[i] = displayIndexes[index]
+	}
+	return view
+}
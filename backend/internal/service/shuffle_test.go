@@ -0,0 +1,208 @@
+package service
+
+import (
+	"slices"
+	"testing"
+
+	"CorrectQuiz.com/quiz/internal/entity"
+	"github.com/google/uuid"
+)
+
+func TestOrderingChoicesAreNeverShownInCorrectOrder(t *testing.T) {
+	for _, choiceCount := range []int{2, 3, 4} {
+		choices := make([]entity.QuizChoice, choiceCount)
+		for i := range choices {
+			choices[i] = entity.QuizChoice{Name: string(rune('A' + i)), Position: i}
+		}
+		question := entity.QuizQuestion{Type: entity.QuestionTypeOrdering, Choices: choices}
+		game := Game{Quiz: entity.Quiz{Questions: []entity.QuizQuestion{question}}}
+
+		for attempt := 0; attempt < 200; attempt++ {
+			player := &Player{Id: uuid.New()}
+			order := game.choiceOrderLocked(player, 0)
+			if order == nil {
+				t.Fatalf("%d choices: ordering question was not shuffled without ShuffleChoices", choiceCount)
+			}
+			if slices.Equal(order, correctChoiceOrder(question)) {
+				t.Fatalf("%d choices: player was shown the correct order %v", choiceCount, order)
+			}
+			if !slices.Equal(game.choiceOrderLocked(player, 0), order) {
+				t.Fatalf("%d choices: order changed between calls for the same player", choiceCount)
+			}
+		}
+	}
+}
+
+func TestChoiceShufflingFollowsQuizSetting(t *testing.T) {
+	question := entity.QuizQuestion{
+		Type:    entity.QuestionTypeSingle,
+		Choices: []entity.QuizChoice{{Name: "A"}, {Name: "B"}, {Name: "C"}},
+	}
+
+	game := Game{Quiz: entity.Quiz{Questions: []entity.QuizQuestion{question}}}
+	if order := game.choiceOrderLocked(&Player{Id: uuid.New()}, 0); order != nil {
+		t.Errorf("single choice question shuffled with ShuffleChoices off: %v", order)
+	}
+
+	game.Quiz.ShuffleChoices = true
+	if order := game.choiceOrderLocked(&Player{Id: uuid.New()}, 0); len(order) != len(question.Choices) {
+		t.Errorf("single choice question not shuffled with ShuffleChoices on: %v", order)
+	}
+}
+
+func shuffledAnswerGame(t *testing.T, question entity.QuizQuestion, players int) (*Game, []*Player) {
+	t.Helper()
+	netService, err := Net(nil, nil, DefaultCodeOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	quiz := entity.Quiz{Questions: []entity.QuizQuestion{question}}
+	quiz.ShuffleChoices = true
+	game := newGame(quiz, nil, netService)
+	t.Cleanup(game.cancelFunc)
+	game.State = PlayState
+
+	for i := 0; i < players; i++ {
+		game.Players = append(game.Players, &Player{Id: uuid.New()})
+	}
+	return &game, game.Players
+}
+
+func displayIndexOf(order []int, canonical int) int {
+	return slices.Index(order, canonical)
+}
+
+func assertPlayersSeeDifferentOrders(t *testing.T, game *Game, players []*Player) {
+	t.Helper()
+	first := game.choiceOrderLocked(players[0], 0)
+	for _, player := range players[1:] {
+		if !slices.Equal(game.choiceOrderLocked(player, 0), first) {
+			return
+		}
+	}
+	t.Errorf("all %d players were shown the same order %v", len(players), first)
+}
+
+func TestShuffledSingleChoiceAnswersGradeCanonically(t *testing.T) {
+	question := leakTestQuestion(entity.QuestionTypeSingle)
+	correct := correctChoiceIndexes(question)
+	game, players := shuffledAnswerGame(t, question, 24)
+	assertPlayersSeeDifferentOrders(t, game, players)
+
+	for i, player := range players {
+		order := game.choiceOrderLocked(player, 0)
+		display := displayIndexOf(order, correct[0])
+		if i%2 == 1 {
+			display = (display + 1) % len(order)
+		}
+
+		game.OnPlayerAnswer(0, PlayerAnswer{Choice: display}, player)
+
+		if want := order[display]; player.CurrentAnswer.Choice != want {
+			t.Fatalf("display choice %d with order %v stored as %d, want %d", display, order, player.CurrentAnswer.Choice, want)
+		}
+		wantCredit := 1.0
+		if i%2 == 1 {
+			wantCredit = 0
+		}
+		if credit := gradeAnswer(question, player.CurrentAnswer); credit != wantCredit {
+			t.Errorf("order %v, display choice %d: credit %v, want %v", order, display, credit, wantCredit)
+		}
+		if view := game.playerViewIndexesLocked(player, 0, correct); !slices.Equal(view, []int{displayIndexOf(order, correct[0])}) {
+			t.Errorf("order %v: correct answer shown at %v, want %d", order, view, displayIndexOf(order, correct[0]))
+		}
+	}
+}
+
+func TestShuffledMultipleChoiceAnswersGradeCanonically(t *testing.T) {
+	question := leakTestQuestion(entity.QuestionTypeMultiple)
+	question.Choices[0].Correct = true
+	correct := correctChoiceIndexes(question)
+	game, players := shuffledAnswerGame(t, question, 24)
+	assertPlayersSeeDifferentOrders(t, game, players)
+
+	for i, player := range players {
+		order := game.choiceOrderLocked(player, 0)
+		selected := []int{}
+		for _, canonical := range correct {
+			selected = append(selected, displayIndexOf(order, canonical))
+		}
+		wantCredit := 1.0
+		if i%2 == 1 {
+			selected = append(selected, displayIndexOf(order, 1))
+			wantCredit = 0
+		}
+
+		game.OnPlayerAnswer(0, PlayerAnswer{Choices: selected}, player)
+
+		stored := slices.Sorted(slices.Values(player.CurrentAnswer.Choices))
+		want := []int{0, 2}
+		if i%2 == 1 {
+			want = []int{0, 1, 2}
+		}
+		if !slices.Equal(stored, want) {
+			t.Fatalf("order %v, display choices %v stored as %v, want %v", order, selected, stored, want)
+		}
+		if credit := gradeAnswer(question, player.CurrentAnswer); credit != wantCredit {
+			t.Errorf("order %v, display choices %v: credit %v, want %v", order, selected, credit, wantCredit)
+		}
+
+		view := slices.Sorted(slices.Values(game.playerViewIndexesLocked(player, 0, correct)))
+		wantView := slices.Sorted(slices.Values(selected[:len(correct)]))
+		if !slices.Equal(view, wantView) {
+			t.Errorf("order %v: correct answers shown at %v, want %v", order, view, wantView)
+		}
+	}
+}
+
+func TestShuffledOrderingAnswersGradeCanonically(t *testing.T) {
+	for _, mode := range []entity.ScoringMode{entity.ScoringAllOrNothing, entity.ScoringPerPosition} {
+		t.Run(string(mode), func(t *testing.T) {
+			question := leakTestQuestion(entity.QuestionTypeOrdering)
+			question.ScoringMode = mode
+			correct := correctChoiceOrder(question)
+			game, players := shuffledAnswerGame(t, question, 24)
+			assertPlayersSeeDifferentOrders(t, game, players)
+
+			for i, player := range players {
+				order := game.choiceOrderLocked(player, 0)
+				submitted := make([]int, len(correct))
+				if i%2 == 0 {
+					for position, canonical := range correct {
+						submitted[position] = displayIndexOf(order, canonical)
+					}
+				} else {
+					for position := range submitted {
+						submitted[position] = position
+					}
+				}
+
+				game.OnPlayerAnswer(0, PlayerAnswer{Order: submitted}, player)
+
+				credit := gradeAnswer(question, player.CurrentAnswer)
+				if i%2 == 0 {
+					if !slices.Equal(player.CurrentAnswer.Order, correct) {
+						t.Fatalf("order %v, display order %v stored as %v, want %v", order, submitted, player.CurrentAnswer.Order, correct)
+					}
+					if credit != 1 {
+						t.Errorf("order %v: correct arrangement earned %v credit, want 1", order, credit)
+					}
+				} else {
+					if !slices.Equal(player.CurrentAnswer.Order, order) {
+						t.Fatalf("order %v, display order %v stored as %v, want the shown order", order, submitted, player.CurrentAnswer.Order)
+					}
+					if credit >= 1 {
+						t.Errorf("order %v: submitting the shown order earned full credit", order)
+					}
+				}
+
+				view := game.playerViewIndexesLocked(player, 0, correct)
+				for position, canonical := range correct {
+					if order[view[position]] != canonical {
+						t.Errorf("order %v: view index %v does not point at canonical choice %d", order, view, canonical)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+const maxTeams = 8
+
+type TeamAggregate string
+
+const (
+	TeamAggregateSum     TeamAggregate = "sum"
+	TeamAggregateAverage TeamAggregate = "average"
+	TeamAggregateBest    TeamAggregate = "best"
+)
+
+type TeamOptions struct {
+	Count       int           `json:"count"`
+	Aggregate   TeamAggregate `json:"aggregate"`
+	AutoBalance bool          `json:"autoBalance"`
+}
+
+type TeamStanding struct {
+	Team    int    `json:"team"`
+	Name    string `json:"name"`
+	Points  int    `json:"points"`
+	Members int    `json:"members"`
+}
+
+type TeamAssignPacket struct {
+	PlayerId uuid.UUID `json:"playerId"`
+	Team     int       `json:"team"`
+}
+
+type JoinTeamPacket struct {
+	Team int `json:"team"`
+}
+
+func (o *TeamOptions) valid() bool {
+	return o != nil && o.Count >= 2 && o.Count <= maxTeams
+}
+
+func TeamName(team int) string {
+	if team <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("Team %d", team)
+}
+
+func (g *Game) smallestTeamLocked() int {
+	sizes := make([]int, g.Teams.Count+1)
+	for _, player := range g.Players {
+		if player.Team > 0 && player.Team <= g.Teams.Count {
+			sizes[player.Team]++
+		}
+	}
+
+	smallest := 1
+	for team := 2; team <= g.Teams.Count; team++ {
+		if sizes[team] < sizes[smallest] {
+			smallest = team
+		}
+	}
+	return smallest
+}
+
+func (g *Game) autoAssignTeamLocked(player *Player) {
+	if g.Teams == nil || !g.Teams.AutoBalance || player.Team != 0 {
+		return
+	}
+	player.Team = g.smallestTeamLocked()
+}
+
+func (g *Game) JoinTeam(player *Player, team int) error {
+	if g.Teams == nil {
+		return errors.New("team mode is not enabled")
+	}
+	if g.State != LobbyState {
+		return errors.New("teams can only be changed in the lobby")
+	}
+	if team < 1 || team > g.Teams.Count {
+		return errors.New("invalid team")
+	}
+
+	g.playersMutex.Lock()
+	player.Team = team
+	g.playersMutex.Unlock()
+
+	g.sendTeamAssignment(player)
+	return nil
+}
+
+func (g *Game) balanceUnassignedTeams() {
+	if g.Teams == nil {
+		return
+	}
+
+	g.playersMutex.Lock()
+	assigned := []*Player{}
+	for _, player := range g.Players {
+		if player.Team == 0 {
+			player.Team = g.smallestTeamLocked()
+			assigned = append(assigned, player)
+		}
+	}
+	g.playersMutex.Unlock()
+
+	for _, player := range assigned {
+		g.sendTeamAssignment(player)
+	}
+}
+
+func (g *Game) sendTeamAssignment(player *Player) {
+	packet := TeamAssignPacket{
+		PlayerId: player.Id,
+		Team:     player.Team,
+	}
+	g.netService.SendPacket(player.Connection, packet)
+	g.netService.SendPacket(g.Host, packet)
+}
+
+func (g *Game) teamStandings() []TeamStanding {
+	if g.Teams == nil {
+		return nil
+	}
+
+	g.playersMutex.RLock()
+	players := append([]*Player{}, g.Players...)
+	for _, player := range g.departedPlayers {
+		if player.answeredAny || player.leftAfterStart {
+			players = append(players, player)
+		}
+	}
+	started := g.State != LobbyState
+	g.playersMutex.RUnlock()
+
+	standings := make([]TeamStanding, g.Teams.Count)
+	for i := range standings {
+		standings[i].Team = i + 1
+		standings[i].Name = TeamName(i + 1)
+	}
+
+	for _, player := range players {
+		if player.Team < 1 || player.Team > g.Teams.Count {
+			continue
+		}
+		standing := &standings[player.Team-1]
+		standing.Members++
+		switch g.Teams.Aggregate {
+		case TeamAggregateBest:
+			standing.Points = max(standing.Points, player.Points)
+		default:
+			standing.Points += player.Points
+		}
+	}
+
+	if started {
+		played := standings[:0]
+		for _, standing := range standings {
+			if standing.Members > 0 {
+				played = append(played, standing)
+			}
+		}
+		standings = played
+	}
+
+	if g.Teams.Aggregate == TeamAggregateAverage {
+		for i := range standings {
+			if standings[i].Members > 0 {
+				standings[i].Points /= standings[i].Members
+			}
+		}
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		return standings[i].Points > standings[j].Points
+	})
+	return standings
+}
+
+func teamRanks(standings []TeamStanding) map[int]int {
+	ranks := make(map[int]int)
+	for i, standing := range standings {
+		ranks[standing.Team] = i + 1
+	}
+	return ranks
+}
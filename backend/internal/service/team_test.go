@@ -0,0 +1,91 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestTeamStandingsIgnoreLobbyDepartures(t *testing.T) {
+	game := Game{
+		Teams: &TeamOptions{Count: 3, Aggregate: TeamAggregateAverage},
+		State: LobbyState,
+	}
+
+	stayed := &Player{Id: uuid.New(), Team: 1}
+	lobbyLeaver := &Player{Id: uuid.New(), Team: 1}
+	onlyLobbyMember := &Player{Id: uuid.New(), Team: 3}
+	playLeaver := &Player{Id: uuid.New(), Team: 2}
+	teammate := &Player{Id: uuid.New(), Team: 2}
+	game.Players = []*Player{stayed, lobbyLeaver, onlyLobbyMember, playLeaver, teammate}
+
+	game.removePlayerLocked(lobbyLeaver.Id)
+	game.removePlayerLocked(onlyLobbyMember.Id)
+
+	game.State = PlayState
+	stayed.Points = 100
+	playLeaver.Points = 0
+	teammate.Points = 200
+	game.removePlayerLocked(playLeaver.Id)
+
+	standings := game.teamStandings()
+	if len(standings) != 2 {
+		t.Fatalf("got %d standings, want 2 (team 3 never played): %+v", len(standings), standings)
+	}
+
+	byTeam := make(map[int]TeamStanding)
+	for _, standing := range standings {
+		byTeam[standing.Team] = standing
+	}
+	if got := byTeam[1]; got.Members != 1 || got.Points != 100 {
+		t.Errorf("team 1 = %+v, want 1 member averaging 100 without the lobby leaver", got)
+	}
+	if got := byTeam[2]; got.Members != 2 || got.Points != 100 {
+		t.Errorf("team 2 = %+v, want 2 members averaging 100 including the player who left mid-game", got)
+	}
+}
+
+func TestTeamStandingsCountSelfPacedLeaversWhoAnswered(t *testing.T) {
+	game := Game{
+		Teams:      &TeamOptions{Count: 2, Aggregate: TeamAggregateAverage},
+		State:      PlayState,
+		Assignment: &AssignmentOptions{},
+	}
+
+	answered := &Player{Id: uuid.New(), Team: 1, Points: 90, answeredAny: true}
+	idle := &Player{Id: uuid.New(), Team: 1}
+	finishing := &Player{Id: uuid.New(), Team: 1, Points: 30}
+	game.Players = []*Player{answered, idle, finishing}
+
+	game.removePlayerLocked(answered.Id)
+	game.removePlayerLocked(idle.Id)
+
+	standings := game.teamStandings()
+	if len(standings) != 1 || standings[0].Members != 2 || standings[0].Points != 60 {
+		t.Errorf("standings = %+v, want 2 members averaging 60", standings)
+	}
+}
+
+func TestTeamStandingsCountKickedPlayers(t *testing.T) {
+	game := Game{
+		Teams: &TeamOptions{Count: 2, Aggregate: TeamAggregateSum},
+		State: PlayState,
+	}
+
+	kicked := &Player{Id: uuid.New(), Team: 1, Points: 70}
+	teammate := &Player{Id: uuid.New(), Team: 1, Points: 30}
+	rival := &Player{Id: uuid.New(), Team: 2, Points: 50}
+	game.Players = []*Player{kicked, teammate, rival}
+
+	if err := game.KickPlayer(kicked.Id.String()); err != nil {
+		t.Fatal(err)
+	}
+	if game.GetPlayer(kicked.Id) != nil {
+		t.Fatal("kicked player is still in the game")
+	}
+
+	standings := game.teamStandings()
+	if len(standings) != 2 || standings[0].Team != 1 || standings[0].Members != 2 || standings[0].Points != 100 {
+		t.Errorf("standings = %+v, want team 1 first with 2 members totalling 100", standings)
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"CorrectQuiz.com/quiz/internal/entity"
+	"golang.org/x/text/unicode/norm"
+)
+
+const MaxTextAnswerRunes = 200
+
+func isThaiRune(r rune) bool {
+	return r >= 0x0E00 && r <= 0x0E7F
+}
+
+func isThaiToneMark(r rune) bool {
+	return r >= 0x0E48 && r <= 0x0E4B
+}
+
+func normalizeTextAnswer(text string, options entity.TextMatchOptions) string {
+	if !options.CaseSensitive {
+		text = strings.ToLower(text)
+	}
+
+	if options.KeepWhitespace {
+		text = strings.Join(strings.Fields(text), " ")
+	} else {
+		text = strings.Join(strings.Fields(text), "")
+	}
+
+	var builder strings.Builder
+	for _, r := range norm.NFD.String(text) {
+		if !options.KeepToneMarks && isThaiToneMark(r) {
+			continue
+		}
+		if !options.KeepDiacritics && unicode.Is(unicode.Mn, r) && !isThaiRune(r) {
+			continue
+		}
+		builder.WriteRune(r)
+	}
+
+	return norm.NFC.String(builder.String())
+}
+
+func editDistance(a string, b string) int {
+	ra := []rune(a)
+	rb := []rune(b)
+
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(rb)]
+}
+
+func matchesAcceptedAnswer(question entity.QuizQuestion, text string) bool {
+	normalized := normalizeTextAnswer(text, question.TextMatch)
+	if normalized == "" {
+		return false
+	}
+
+	length := utf8.RuneCountInString(normalized)
+	for _, accepted := range question.AcceptedAnswers {
+		candidate := normalizeTextAnswer(accepted, question.TextMatch)
+		if candidate == "" {
+			continue
+		}
+		if abs(length-utf8.RuneCountInString(candidate)) > question.TextMatch.MaxDistance {
+			continue
+		}
+		if editDistance(normalized, candidate) <= question.TextMatch.MaxDistance {
+			return true
+		}
+	}
+	return false
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"CorrectQuiz.com/quiz/internal/entity"
+)
+
+func TestMatchesAcceptedAnswer(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		maxDistance int
+		want        bool
+	}{
+		{"exact", "กรุงเทพมหานคร", 0, true},
+		{"surrounding whitespace ignored", " กรุงเทพมหานคร ", 0, true},
+		{"one typo within distance", "กรุงเทพมหานคน", 1, true},
+		{"one typo without distance", "กรุงเทพมหานคน", 0, false},
+		{"missing rune within distance", "กรุงเทพมหานค", 1, true},
+		{"length difference beyond distance", "กรุงเทพ", 2, false},
+		{"very long answer", strings.Repeat("ก", MaxTextAnswerRunes), 2, false},
+		{"empty", "", 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			question := entity.QuizQuestion{
+				Type:            entity.QuestionTypeText,
+				AcceptedAnswers: []string{"กรุงเทพมหานคร"},
+				TextMatch:       entity.TextMatchOptions{MaxDistance: tt.maxDistance},
+			}
+			if got := matchesAcceptedAnswer(question, tt.text); got != tt.want {
+				t.Errorf("matchesAcceptedAnswer(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchesAcceptedAnswerOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		accepted string
+		text     string
+		options  entity.TextMatchOptions
+		want     bool
+	}{
+		{"case ignored", "Bangkok", "bANGKOK", entity.TextMatchOptions{}, true},
+		{"case sensitive match", "Bangkok", "Bangkok", entity.TextMatchOptions{CaseSensitive: true}, true},
+		{"case sensitive mismatch", "Bangkok", "bangkok", entity.TextMatchOptions{CaseSensitive: true}, false},
+		{"inner whitespace ignored", "New York", "NewYork", entity.TextMatchOptions{}, true},
+		{"inner whitespace kept", "New York", "NewYork", entity.TextMatchOptions{KeepWhitespace: true}, false},
+		{"repeated whitespace collapsed", "New York", "New   York", entity.TextMatchOptions{KeepWhitespace: true}, true},
+		{"diacritics ignored", "café", "cafe", entity.TextMatchOptions{}, true},
+		{"diacritics kept", "café", "cafe", entity.TextMatchOptions{KeepDiacritics: true}, false},
+		{"diacritics kept match", "café", "café", entity.TextMatchOptions{KeepDiacritics: true}, true},
+		{"decomposed diacritics kept match", "caf\u00e9", "cafe\u0301", entity.TextMatchOptions{KeepDiacritics: true}, true},
+		{"thai mai ek ignored", "ไม่", "ไม", entity.TextMatchOptions{}, true},
+		{"thai mai tho ignored", "ไม้", "ไม", entity.TextMatchOptions{}, true},
+		{"thai mai tri ignored", "โต๊ะ", "โตะ", entity.TextMatchOptions{}, true},
+		{"thai mai chattawa ignored", "จ๋า", "จา", entity.TextMatchOptions{}, true},
+		{"thai tone marks kept", "ไม้", "ไม", entity.TextMatchOptions{KeepToneMarks: true}, false},
+		{"thai tone marks kept differ", "ไม้", "ไม่", entity.TextMatchOptions{KeepToneMarks: true}, false},
+		{"thai tone marks kept match", "ไม้", "ไม้", entity.TextMatchOptions{KeepToneMarks: true}, true},
+		{"thai vowels are not diacritics", "กิน", "กน", entity.TextMatchOptions{}, false},
+		{"thai tone marks survive keeping only diacritics", "ไม้", "ไม", entity.TextMatchOptions{KeepDiacritics: true}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			question := entity.QuizQuestion{
+				Type:            entity.QuestionTypeText,
+				AcceptedAnswers: []string{tt.accepted},
+				TextMatch:       tt.options,
+			}
+			if got := matchesAcceptedAnswer(question, tt.text); got != tt.want {
+				t.Errorf("matchesAcceptedAnswer(%q, %q) = %v, want %v", tt.accepted, tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextAnswerLengthLimit(t *testing.T) {
+	netService, err := Net(nil, nil, DefaultCodeOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	hostConnection, _ := captureConnection(netService)
+	game := newGame(entity.Quiz{
+		Name:      "text limit",
+		Questions: []entity.QuizQuestion{leakTestQuestion(entity.QuestionTypeText)},
+	}, hostConnection, netService)
+	if err := netService.games.Register(&game); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(game.cancelFunc)
+
+	connection, client := captureConnection(netService)
+	client.negotiated = true
+	player := game.OnPlayerJoin("player", connection)
+	game.Start()
+	drainPackets(client)
+
+	send := func(text string) {
+		data, err := json.Marshal(QuestionAnswerPacket{Question: 0, Text: text})
+		if err != nil {
+			t.Fatal(err)
+		}
+		netService.OnIncomingMessage(connection, 2, append([]byte{7}, data...))
+	}
+
+	send(strings.Repeat("ก", MaxTextAnswerRunes+1))
+	packets := drainPackets(client)
+	if len(packets) != 1 || packets[0][0] != 28 {
+		t.Fatalf("over-long answer got packets %v, want one error", packetIds(packets))
+	}
+	var errorPacket ErrorPacket
+	if err := json.Unmarshal(packets[0][1:], &errorPacket); err != nil {
+		t.Fatal(err)
+	}
+	if errorPacket.Code != ErrorCodeInvalidPayload {
+		t.Errorf("error code = %s, want %s", errorPacket.Code, ErrorCodeInvalidPayload)
+	}
+	if player.Answered {
+		t.Fatal("over-long answer was recorded")
+	}
+
+	send(strings.Repeat("ก", MaxTextAnswerRunes))
+	if !player.Answered {
+		t.Error("answer at the length limit was rejected")
+	}
+}
@@ -0,0 +1,102 @@
+package service
+
+import "CorrectQuiz.com/quiz/internal/entity"
+
+type HostChoice struct {
+	ID       uint    `json:"id"`
+	Name     string  `json:"name"`
+	Correct  bool    `json:"correct"`
+	Position int     `json:"position"`
+	ImageUrl *string `json:"imageUrl,omitempty"`
+}
+
+type HostQuestion struct {
+	ID               uint                    `json:"id"`
+	Name             string                  `json:"name"`
+	Time             int                     `json:"time"`
+	ImageUrl         string                  `json:"imageUrl,omitempty"`
+	Type             entity.QuestionType     `json:"type"`
+	ScoringMode      entity.ScoringMode      `json:"scoringMode"`
+	PointsMultiplier float64                 `json:"pointsMultiplier"`
+	Choices          []HostChoice            `json:"choices"`
+	AcceptedAnswers  []string                `json:"acceptedAnswers,omitempty"`
+	TextMatch        entity.TextMatchOptions `json:"textMatch"`
+	Numeric          entity.NumericOptions   `json:"numeric"`
+}
+
+func newHostQuestion(question entity.QuizQuestion) HostQuestion {
+	choices := make([]HostChoice, len(question.Choices))
+	for i, choice := range question.Choices {
+		choices[i] = HostChoice{
+			ID:       choice.ID,
+			Name:     choice.Name,
+			Correct:  choice.Correct,
+			Position: choice.Position,
+			ImageUrl: choice.ImageUrl,
+		}
+	}
+
+	return HostQuestion{
+		ID:               question.ID,
+		Name:             question.Name,
+		Time:             question.Time,
+		ImageUrl:         question.ImageUrl,
+		Type:             question.Type,
+		ScoringMode:      question.ScoringMode,
+		PointsMultiplier: question.Multiplier(),
+		Choices:          choices,
+		AcceptedAnswers:  question.AcceptedAnswers,
+		TextMatch:        question.TextMatch,
+		Numeric:          question.Numeric,
+	}
+}
+
+type PlayerChoice struct {
+	Name     string  `json:"name"`
+	ImageUrl *string `json:"imageUrl,omitempty"`
+}
+
+type NumericRange struct {
+	Min  float64 `json:"min"`
+	Max  float64 `json:"max"`
+	Step float64 `json:"step"`
+}
+
+type PlayerQuestion struct {
+	ID               uint                `json:"id"`
+	Name             string              `json:"name"`
+	Time             int                 `json:"time"`
+	ImageUrl         string              `json:"imageUrl,omitempty"`
+	Type             entity.QuestionType `json:"type"`
+	PointsMultiplier float64             `json:"pointsMultiplier"`
+	Choices          []PlayerChoice      `json:"choices"`
+	Numeric          *NumericRange       `json:"numeric,omitempty"`
+}
+
+func newPlayerQuestion(question entity.QuizQuestion) PlayerQuestion {
+	choices := make([]PlayerChoice, len(question.Choices))
+	for i, choice := range question.Choices {
+		choices[i] = PlayerChoice{
+			Name:     choice.Name,
+			ImageUrl: choice.ImageUrl,
+		}
+	}
+
+	playerQuestion := PlayerQuestion{
+		ID:               question.ID,
+		Name:             question.Name,
+		Time:             question.Time,
+		ImageUrl:         question.ImageUrl,
+		Type:             question.Type,
+		PointsMultiplier: question.Multiplier(),
+		Choices:          choices,
+	}
+	if question.Type == entity.QuestionTypeNumeric {
+		playerQuestion.Numeric = &NumericRange{
+			Min:  question.Numeric.Min,
+			Max:  question.Numeric.Max,
+			Step: question.Numeric.Step,
+		}
+	}
+	return playerQuestion
+}
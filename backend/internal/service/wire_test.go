@@ -0,0 +1,213 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"CorrectQuiz.com/quiz/internal/entity"
+	"github.com/gofiber/contrib/websocket"
+)
+
+const (
+	leakAcceptedAnswer = "กรุงเทพมหานคร"
+	leakNumericCorrect = 42.5
+)
+
+var answerFields = map[string]bool{
+	"correct":            true,
+	"position":           true,
+	"acceptedAnswers":    true,
+	"tolerance":          true,
+	"correctValue":       true,
+	"correctAnswerIndex": true,
+	"textMatch":          true,
+	"scoringMode":        true,
+}
+
+func leakTestQuestion(questionType entity.QuestionType) entity.QuizQuestion {
+	question := entity.QuizQuestion{
+		ID:              1,
+		Name:            string(questionType),
+		Time:            20,
+		Type:            questionType,
+		AcceptedAnswers: []string{leakAcceptedAnswer},
+		Numeric: entity.NumericOptions{
+			Min:       0,
+			Max:       100,
+			Step:      0.5,
+			Correct:   leakNumericCorrect,
+			Tolerance: 1,
+		},
+	}
+	for i, name := range []string{"A", "B", "C", "D"} {
+		question.Choices = append(question.Choices, entity.QuizChoice{
+			ID:       uint(i + 1),
+			Name:     name,
+			Correct:  i == 2,
+			Position: i,
+		})
+	}
+	return question
+}
+
+func captureConnection(netService *NetService) (*websocket.Conn, *Client) {
+	connection := &websocket.Conn{}
+	client := &Client{
+		connection: connection,
+		codec:      JSONCodec,
+		send:       make(chan outboundMessage, clientSendBuffer),
+		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+	netService.clientsMutex.Lock()
+	netService.clients[connection] = client
+	netService.clientsMutex.Unlock()
+	return connection, client
+}
+
+func drainPackets(client *Client) [][]byte {
+	packets := [][]byte{}
+	for {
+		select {
+		case message := <-client.send:
+			if message.messageType == clientCloseMessage {
+				continue
+			}
+			packets = append(packets, message.data)
+		default:
+			return packets
+		}
+	}
+}
+
+func findAnswerField(value any) (string, bool) {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, child := range value {
+			if answerFields[key] {
+				return key, true
+			}
+			if field, ok := findAnswerField(child); ok {
+				return field, true
+			}
+		}
+	case []any:
+		for _, child := range value {
+			if field, ok := findAnswerField(child); ok {
+				return field, true
+			}
+		}
+	}
+	return "", false
+}
+
+func assertNoAnswerData(t *testing.T, stage string, packets [][]byte) {
+	t.Helper()
+	if len(packets) == 0 {
+		t.Fatalf("%s: no packets were captured", stage)
+	}
+
+	for _, packet := range packets {
+		packetId, payload := packet[0], packet[1:]
+
+		var decoded any
+		if err := json.Unmarshal(payload, &decoded); err != nil {
+			t.Fatalf("%s: packet %d is not valid JSON: %v", stage, packetId, err)
+		}
+		if field, ok := findAnswerField(decoded); ok {
+			t.Errorf("%s: packet %d contains answer field %q: %s", stage, packetId, field, payload)
+		}
+		if bytes.Contains(payload, []byte(leakAcceptedAnswer)) {
+			t.Errorf("%s: packet %d contains an accepted answer: %s", stage, packetId, payload)
+		}
+		if bytes.Contains(payload, []byte(strconv.FormatFloat(leakNumericCorrect, 'f', -1, 64))) {
+			t.Errorf("%s: packet %d contains the numeric answer: %s", stage, packetId, payload)
+		}
+	}
+}
+
+var leakTestQuestionTypes = []entity.QuestionType{
+	entity.QuestionTypeSingle,
+	entity.QuestionTypeMultiple,
+	entity.QuestionTypeText,
+	entity.QuestionTypeNumeric,
+	entity.QuestionTypeOrdering,
+	entity.QuestionTypePoll,
+	entity.QuestionTypeWordCloud,
+}
+
+func TestLivePlayerPacketsBeforeRevealHideAnswers(t *testing.T) {
+	for _, questionType := range leakTestQuestionTypes {
+		for _, shuffleChoices := range []bool{false, true} {
+			name := string(questionType) + "/shuffle=" + strconv.FormatBool(shuffleChoices)
+			t.Run(name, func(t *testing.T) {
+				netService, err := Net(nil, nil, DefaultCodeOptions())
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				quiz := entity.Quiz{
+					Name:      "leak test",
+					Questions: []entity.QuizQuestion{leakTestQuestion(questionType)},
+				}
+				quiz.ShuffleChoices = shuffleChoices
+				game := newGame(quiz, nil, netService)
+				defer game.cancelFunc()
+
+				answeringConnection, answeringClient := captureConnection(netService)
+				waitingConnection, waitingClient := captureConnection(netService)
+
+				answering := game.OnPlayerJoin("answering", answeringConnection)
+				game.OnPlayerJoin("waiting", waitingConnection)
+				game.Start()
+
+				value := leakNumericCorrect
+				game.OnPlayerAnswer(0, PlayerAnswer{
+					Choice:  2,
+					Choices: []int{2},
+					Text:    leakAcceptedAnswer,
+					Value:   &value,
+					Order:   []int{0, 1, 2, 3},
+				}, answering)
+
+				assertNoAnswerData(t, "answering player", drainPackets(answeringClient))
+				assertNoAnswerData(t, "waiting player", drainPackets(waitingClient))
+
+				resumedConnection, resumedClient := captureConnection(netService)
+				game.OnPlayerResume(game.Players[1], resumedConnection)
+				assertNoAnswerData(t, "resumed player", drainPackets(resumedClient))
+			})
+		}
+	}
+}
+
+func TestSelfPacedPlayerPacketsBeforeAnswerHideAnswers(t *testing.T) {
+	for _, questionType := range leakTestQuestionTypes {
+		t.Run(string(questionType), func(t *testing.T) {
+			netService, err := Net(nil, nil, DefaultCodeOptions())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			quiz := entity.Quiz{
+				Name:      "leak test",
+				Questions: []entity.QuizQuestion{leakTestQuestion(questionType)},
+			}
+			quiz.ShuffleChoices = true
+			game := newGame(quiz, nil, netService)
+			game.Assignment = &AssignmentOptions{}
+			game.State = PlayState
+			game.selfPacedCorrect = make(map[int]int)
+			defer game.cancelFunc()
+
+			connection, client := captureConnection(netService)
+			player := game.OnPlayerJoin("self paced", connection)
+			game.showSelfPacedQuestion(player)
+			defer player.questionTimer.Stop()
+
+			assertNoAnswerData(t, "self-paced player", drainPackets(client))
+		})
+	}
+}
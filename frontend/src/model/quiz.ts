@@ -1,7 +1,13 @@
+export type ScoringStrategy = "rank_bonus" | "time_decay" | "flat" | "exam";
+
 export interface Quiz {
     id: number;
     name: string;
     questions: QuizQuestion[];
+    scoringStrategy?: ScoringStrategy;
+    bonusRoundMultiplier?: number;
+    shuffleQuestions?: boolean;
+    shuffleChoices?: boolean;
 }
 
 export interface User {
@@ -13,22 +19,50 @@ export interface User {
 export interface Player {
     id: string;
     name: string;
+    team?: number;
 }
 
+export type QuestionType = "single" | "multiple" | "text" | "numeric" | "ordering" | "poll" | "word_cloud";
+
+export type ScoringMode = "all_or_nothing" | "proportional" | "penalty" | "exact" | "tolerance" | "linear" | "per_position";
+
 export interface QuizQuestion {
     index: any;
     id: number;
     name: string;
     time: number;
+    type?: QuestionType;
+    scoringMode?: ScoringMode;
+    pointsMultiplier?: number;
+    acceptedAnswers?: string[];
+    textMatch?: TextMatchOptions;
+    numeric?: NumericOptions;
     choices: QuizChoice[];
     correctAnswerIndex: number;
     imageUrl?: string;
 }
 
+export interface TextMatchOptions {
+    caseSensitive: boolean;
+    keepWhitespace: boolean;
+    keepDiacritics: boolean;
+    keepToneMarks: boolean;
+    maxDistance: number;
+}
+
+export interface NumericOptions {
+    min: number;
+    max: number;
+    step: number;
+    correct: number;
+    tolerance: number;
+}
+
 export interface QuizChoice {
     id: number;
     name: string;
     correct: boolean;
+    position?: number;
     imageUrl?: string;
 }
 
@@ -36,4 +70,43 @@ export interface RegisterError {
     errorStatus: number;
 }
 
-export const COLORS = ["bg-pink-400", "bg-orange-200", "bg-green-200", "bg-purple-200"];
\ No newline at end of file
+export const COLORS = ["bg-pink-400", "bg-orange-200", "bg-green-200", "bg-purple-200"];
+export interface ChoiceAnalysis {
+    choiceId: number;
+    name: string;
+    correct: boolean;
+    selectedCount: number;
+    selectionRate: number;
+}
+
+export interface QuestionAnalysis {
+    questionId: number;
+    name: string;
+    type: QuestionType;
+    graded: boolean;
+    presented: number;
+    answered: number;
+    percentCorrect: number;
+    averageCredit: number;
+    averageResponseTimeMs: number;
+    discriminationIndex: number | null;
+    choices?: ChoiceAnalysis[];
+}
+
+export interface QuizAnalysis {
+    quizId: number;
+    quizName: string;
+    sessionCount: number;
+    playerCount: number;
+    questions: QuestionAnalysis[];
+}
+
+export interface Assignment {
+    code: string;
+    quizId: number;
+    quizName: string;
+    opensAt: string;
+    closesAt: string;
+    players: number;
+    finished: number;
+}
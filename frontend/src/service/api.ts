@@ -1,4 +1,4 @@
-import type { Quiz, User } from "../model/quiz";
+import type { Assignment, Quiz, QuizAnalysis, User } from "../model/quiz";
 
 interface RegisterError {
     errorStatus: number;
@@ -31,6 +31,16 @@ export class ApiService {
     }
 
 
+    async getQuizAnalysis(id: number): Promise<QuizAnalysis | null> {
+        const response = await fetch(`${BASE_URL}/api/quizzes/${id}/analysis`, {
+            headers: getHeaders(),
+        });
+        if (!response.ok) {
+            return null;
+        }
+        return await response.json();
+    }
+
     async getQuizzes(): Promise<Quiz[]> {
         let response = await fetch(`${BASE_URL}/api/quizzes`, {
             headers: getHeaders(),
@@ -201,5 +211,53 @@ export class ApiService {
         return response.ok;
     }
 
+    async publishAssignment(quizId: number, opensAt: Date, closesAt: Date): Promise<Assignment | null> {
+        const response = await fetch(`${BASE_URL}/api/assignments`, {
+            method: "POST",
+            headers: getHeaders(),
+            body: JSON.stringify({ quizId, opensAt, closesAt }),
+        });
+        if (!response.ok) {
+            return null;
+        }
+        return await response.json();
+    }
+
+    async getAssignments(): Promise<Assignment[]> {
+        const response = await fetch(`${BASE_URL}/api/assignments`, {
+            headers: getHeaders(),
+        });
+        if (!response.ok) {
+            return [];
+        }
+        return await response.json();
+    }
+
+    async closeAssignment(code: string): Promise<boolean> {
+        const response = await fetch(`${BASE_URL}/api/assignments/${code}`, {
+            method: "DELETE",
+            headers: getHeaders(),
+        });
+        return response.ok;
+    }
+
+    async downloadGameResults(gameCode: string, format: "csv" | "xlsx"): Promise<boolean> {
+        const response = await fetch(`${BASE_URL}/api/games/${gameCode}/export/${format}`, {
+            headers: getHeaders(),
+        });
+        if (!response.ok) {
+            return false;
+        }
+
+        const blob = await response.blob();
+        const url = URL.createObjectURL(blob);
+        const link = document.createElement("a");
+        link.href = url;
+        link.download = `quiz_results_${gameCode}.${format === "csv" ? "zip" : format}`;
+        link.click();
+        URL.revokeObjectURL(url);
+        return true;
+    }
+
 }
 export const apiService = new ApiService();
\ No newline at end of file
@@ -1,5 +1,5 @@
 import { get, writable, type Writable } from "svelte/store";
-import { NetService, PacketTypes, type QuestionRevealPacket, type Packet, type HostGamePacket, GameState, type ChangeGameStatePacket, type PlayerJoinPacket, type TickPacket, type QuestionShowPacket, type LeaderboardPacket, LeaderboardEntry, type KickPlayerPacket, PlayerLeavePacket } from "../net";
+import { NetService, PacketTypes, type QuestionRevealPacket, type Packet, type HostGamePacket, GameState, type ChangeGameStatePacket, type PlayerJoinPacket, type TickPacket, type QuestionShowPacket, type LeaderboardPacket, LeaderboardEntry, type KickPlayerPacket, PlayerLeavePacket, type ResumeTokenPacket, type GameSnapshotPacket, type AutoPilotOptions, type GamePausePacket, type ExtendTimePacket, type ErrorPacket, ErrorCode } from "../net";
 import type { Player, QuizQuestion } from "../../model/quiz";
 
 export const leaderboard: Writable<LeaderboardEntry[]> = writable([]);
@@ -11,6 +11,7 @@ export const currentQuestion: Writable<QuizQuestion | null> = writable(null);
 export const correctAnswerIndex: Writable<number[]> = writable([]);
 export const showAnswer: Writable<boolean> = writable(false);
 export const answerCounts: Writable<number[]> = writable([]);
+export const paused: Writable<boolean> = writable(false);
 export const isHostNavigating = writable(false);
 export const isSigningUp = writable(false);
 
@@ -23,30 +24,52 @@ function resetGameStores() {
     correctAnswerIndex.set([]);
     showAnswer.set(false);
     answerCounts.set([]);
+    paused.set(false);
 }
 
 
+const HOST_RESUME_TOKEN_KEY = "hostResumeToken";
+
 class HostGame {
     private net: NetService;
+    private resuming: boolean = false;
 
     public navigate: ((path: string) => void) | undefined;
 
     constructor(navigateFunction?: (path: string) => void) {
         this.net = new NetService();
         this.net.onPacket(p => this.onPacket(p));
+        this.net.onReconnect(() => this.resume());
         if (navigateFunction) {
             this.navigate = navigateFunction;
         }
     }
 
-    hostQuiz(quizId: string) {
+    hostQuiz(quizId: string, autoPilot?: AutoPilotOptions) {
         let packet: HostGamePacket = {
             id: PacketTypes.HostGame,
             quizId: quizId,
+            autoPilot: autoPilot,
         }
         this.net.sendPacket(packet);
     }
 
+    resume(): boolean {
+        const resumeToken = sessionStorage.getItem(HOST_RESUME_TOKEN_KEY);
+        if (!resumeToken) {
+            return false;
+        }
+        console.log("🔁 Resuming host session...");
+        this.resuming = true;
+        let packet: HostGamePacket = {
+            id: PacketTypes.HostGame,
+            quizId: "",
+            resumeToken: resumeToken,
+        }
+        this.net.sendPacket(packet);
+        return true;
+    }
+
     connect(token: string) {
         console.log("Host connecting with token...");
         this.net.connect(token);
@@ -61,6 +84,7 @@ class HostGame {
     }
 
     public signalHostLeaving() {
+        sessionStorage.removeItem(HOST_RESUME_TOKEN_KEY);
         this.net.sendPacket({ id: PacketTypes.HostLeave });
         resetGameStores();
     }
@@ -83,6 +107,30 @@ class HostGame {
         this.net.sendPacket({ id: PacketTypes.NextQuestion });
     }
 
+    setPaused(isPaused: boolean) {
+        let packet: GamePausePacket = {
+            id: PacketTypes.GamePause,
+            paused: isPaused,
+        };
+        this.net.sendPacket(packet);
+    }
+
+    extendTime(seconds: number) {
+        let packet: ExtendTimePacket = {
+            id: PacketTypes.ExtendTime,
+            seconds: seconds,
+        };
+        this.net.sendPacket(packet);
+    }
+
+    endQuestion() {
+        this.net.sendPacket({ id: PacketTypes.EndQuestion });
+    }
+
+    skipQuestion() {
+        this.net.sendPacket({ id: PacketTypes.SkipQuestion });
+    }
+
     public kickPlayer(playerId: string) {
         let packet: KickPlayerPacket = {
             id: PacketTypes.KickPlayer,
@@ -97,15 +145,65 @@ class HostGame {
         switch (packet.id) {
             case PacketTypes.HostGame: {
                 let data = packet as HostGamePacket;
+                this.resuming = false;
                 gameCode.set(data.quizId);
                 break;
             }
+            case PacketTypes.Error: {
+                let data = packet as ErrorPacket;
+                console.error(`❌ [Host] ${data.code}: ${data.message}`);
+                if (this.resuming) {
+                    this.resuming = false;
+                    sessionStorage.removeItem(HOST_RESUME_TOKEN_KEY);
+                    resetGameStores();
+                    alert("ไม่สามารถกลับเข้าสู่เกมเดิมได้ เกมอาจจบไปแล้ว");
+                    if (this.navigate) {
+                        this.navigate('/host');
+                    }
+                    break;
+                }
+                if (data.code === ErrorCode.Unauthorized || data.code === ErrorCode.QuizNotFound) {
+                    alert(data.code === ErrorCode.QuizNotFound ? "ไม่พบชุดคำถามนี้" : "คุณไม่มีสิทธิ์ควบคุมเกมนี้");
+                    if (get(gameCode) === null && this.navigate) {
+                        this.navigate('/');
+                    }
+                }
+                break;
+            }
+            case PacketTypes.ResumeToken: {
+                let data = packet as ResumeTokenPacket;
+                sessionStorage.setItem(HOST_RESUME_TOKEN_KEY, data.token);
+                break;
+            }
+            case PacketTypes.GameSnapshot: {
+                let data = packet as GameSnapshotPacket;
+                gameCode.set(data.code);
+                state.set(data.state);
+                players.set(data.players);
+                tick.set(data.tick);
+                paused.set(data.paused);
+                leaderboard.set(data.leaderboard);
+                currentQuestion.set(data.question ? { ...data.question, index: data.questionIndex } : null);
+                break;
+            }
             case PacketTypes.ChangeGameState: {
                 let data = packet as ChangeGameStatePacket;
+                if (data.state === GameState.GameEndedState) {
+                    alert(`เกมถูกปิดเนื่องจากไม่มีการใช้งาน`);
+                    if (this.navigate) {
+                        this.navigate('/');
+                    }
+                }
                 state.set(data.state);
                 break;
             }
 
+            case PacketTypes.GamePause: {
+                let data = packet as GamePausePacket;
+                paused.set(data.paused);
+                break;
+            }
+
             case PacketTypes.PlayerJoin: {
                 let data = packet as PlayerJoinPacket;
                 players.update(p => [...p, data.player]);
@@ -19,6 +19,34 @@ export enum PacketTypes {
     KickPlayer = 15,
     HostLeave = 16,
     PlayerLeave = 17,
+    ResumeToken = 18,
+    GamePause = 19,
+    GameSnapshot = 20,
+    PollResults = 21,
+    WordCloud = 22,
+    TeamAssign = 23,
+    JoinTeam = 24,
+    ExtendTime = 25,
+    EndQuestion = 26,
+    SkipQuestion = 27,
+    Error = 28,
+    Hello = 29,
+    ProtocolReject = 30,
+}
+
+export const PROTOCOL_VERSION = 1;
+// "msgpack" is deliberately not offered: the server supports it, but this client only encodes and decodes JSON.
+export const CLIENT_CAPABILITIES = ["teams", "autopilot", "self_paced", "host_controls", "shuffle", "errors"];
+
+const RECONNECT_DELAYS_MS = [500, 1000, 2000, 4000, 8000];
+
+export enum ErrorCode {
+    GameNotFound = "game_not_found",
+    NameTaken = "name_taken",
+    GameStarted = "game_started",
+    Unauthorized = "unauthorized",
+    InvalidPayload = "invalid_payload",
+    QuizNotFound = "quiz_not_found",
 }
 
 export enum GameState {
@@ -40,6 +68,64 @@ export interface KickPlayerPacket extends Packet {
 
 export interface HostGamePacket extends Packet {
     quizId: string;
+    resumeToken?: string;
+    teams?: TeamOptions;
+    autoPilot?: AutoPilotOptions;
+}
+
+export interface AutoPilotOptions {
+    revealSeconds: number;
+    intermissionSeconds: number;
+}
+
+export type TeamAggregate = "sum" | "average" | "best";
+
+export interface TeamOptions {
+    count: number;
+    aggregate: TeamAggregate;
+    autoBalance: boolean;
+}
+
+export interface TeamStanding {
+    team: number;
+    name: string;
+    points: number;
+    members: number;
+}
+
+export interface TeamAssignPacket extends Packet {
+    playerId: string;
+    team: number;
+}
+
+export interface JoinTeamPacket extends Packet {
+    team: number;
+}
+
+export interface GamePausePacket extends Packet {
+    paused: boolean;
+}
+
+export interface ExtendTimePacket extends Packet {
+    seconds: number;
+}
+
+export interface EndQuestionPacket extends Packet { }
+
+export interface SkipQuestionPacket extends Packet {
+    questionIndex?: number;
+}
+
+export interface GameSnapshotPacket extends Packet {
+    code: string;
+    state: GameState;
+    paused: boolean;
+    players: Player[];
+    questionIndex: number;
+    question?: QuizQuestion;
+    tick: number;
+    leaderboard: LeaderboardEntry[];
+    teams?: TeamStanding[];
 }
 
 export interface ChangeGameStatePacket extends Packet {
@@ -57,18 +143,32 @@ export interface TickPacket extends Packet {
 export interface ConnectPacket extends Packet {
     code: string;
     name: string;
+    resumeToken?: string;
+}
+
+export interface ResumeTokenPacket extends Packet {
+    token: string;
 }
 
 export interface QuestionShowPacket extends Packet {
     question: QuizQuestion;
     questionIndex: number;
+    pointsMultiplier: number;
+    bonusRound: boolean;
+    selfPaced?: boolean;
 }
 
 export interface QuestionAnswerPacket extends Packet {
     question: number;
     choice: number;
+    choices?: number[];
+    text?: string;
+    value?: number;
+    order?: number[];
 }
 
+export type PlayerAnswer = Partial<Omit<QuestionAnswerPacket, "id" | "question">>;
+
 export interface PlayerRevealPacket extends Packet {
     points: number;
 }
@@ -76,6 +176,10 @@ export interface PlayerRevealPacket extends Packet {
 export interface PlayerAnswerFeedbackPacket extends Packet {
     correctAnswerIndex: number[];
     isCorrect: boolean;
+    graded: boolean;
+    credit: number;
+    acceptedAnswers?: string[];
+    correctValue?: number;
     streakBonus: number;
     maxStreak: number;
 }
@@ -84,25 +188,74 @@ export interface QuestionRevealPacket extends Packet {
     question: QuizQuestion;
     correctAnswerIndex: number[];
     answerCounts: number[];
+    textAnswers?: TextAnswerCount[];
+    histogram?: HistogramBucket[];
+    correctPlacementCounts?: number[];
+    percentages?: number[];
     maxStreak: number;
 }
 
+export interface HistogramBucket {
+    min: number;
+    max: number;
+    count: number;
+}
+
+export interface TextAnswerCount {
+    text: string;
+    count: number;
+    correct: boolean;
+}
+
+export interface PollResultsPacket extends Packet {
+    questionIndex: number;
+    counts: number[];
+    percentages: number[];
+    responses: number;
+}
+
+export interface WordCloudPacket extends Packet {
+    questionIndex: number;
+    words: TextAnswerCount[];
+    responses: number;
+}
+
 export interface LeaderboardEntry {
     name: string;
     points: number;
     correctCount: number;
+    team?: number;
 }
 
 export interface LeaderboardPacket extends Packet {
     points: LeaderboardEntry[];
+    teams?: TeamStanding[];
 }
 
 export interface PlayerRankPacket extends Packet {
     rank: number;
+    team?: number;
+    teamRank?: number;
 }
 
 export interface NextQuestionPacket extends Packet { }
 
+export interface HelloPacket extends Packet {
+    version: number;
+    capabilities: string[];
+}
+
+export interface ProtocolRejectPacket extends Packet {
+    reason: string;
+    minVersion: number;
+    maxVersion: number;
+}
+
+export interface ErrorPacket extends Packet {
+    code: ErrorCode;
+    message: string;
+}
+
 export interface PlayerLeavePacket extends Packet {
     playerId: string;
 }
@@ -115,22 +268,49 @@ export class NetService {
 
     private onPacketCallback?: (packet: any) => void;
     public onDisconnectCallback?: (code: number, reason: string) => void
+    private onReconnectCallback?: () => void;
+
+    private token: string = "";
+    private closedByClient: boolean = false;
+    private reconnecting: boolean = false;
+    private reconnectAttempt: number = 0;
 
     private pendingQueue: Uint8Array[] = [];
 
+    public protocolVersion: number = 0;
+    public capabilities: string[] = [];
+
     constructor() {
         console.log("DEBUG: NetService initialized. Waiting for connect() call.");
     }
 
 
     connect(token: string) {
+        this.token = token;
+        this.closedByClient = false;
+        this.reconnectAttempt = 0;
+        this.open();
+    }
+
+    private open() {
         let baseUrl = (import.meta as any).env.VITE_WS_URL || "ws://localhost:3000/ws";
-        const WS_URL = `${baseUrl}?token=${token}`;
+        const WS_URL = `${baseUrl}?token=${this.token}`;
         console.log("📢 WS URL is:", WS_URL);
         console.log("📢 Connecting WS with token:", WS_URL);
         this.webSocket = new WebSocket(WS_URL);
         this.webSocket.onopen = () => {
             console.log("opened connection");
+            let hello: HelloPacket = {
+                id: PacketTypes.Hello,
+                version: PROTOCOL_VERSION,
+                capabilities: CLIENT_CAPABILITIES,
+            };
+            this.webSocket.send(this.encodePacket(hello));
+            this.reconnectAttempt = 0;
+            if (this.reconnecting) {
+                this.reconnecting = false;
+                this.onReconnectCallback?.();
+            }
             while (this.pendingQueue.length > 0) {
                 const data = this.pendingQueue.shift();
                 if (data) {
@@ -149,11 +329,32 @@ export class NetService {
 
             packet.id = packetId;
 
+            if (packetId === PacketTypes.Hello) {
+                let data = packet as HelloPacket;
+                this.protocolVersion = data.version;
+                this.capabilities = data.capabilities;
+                return;
+            }
+            if (packetId === PacketTypes.ProtocolReject) {
+                let data = packet as ProtocolRejectPacket;
+                console.error(`❌ Protocol rejected: ${data.reason} (server supports v${data.minVersion}-v${data.maxVersion})`);
+                alert("เวอร์ชันของแอปไม่ตรงกับเซิร์ฟเวอร์ กรุณารีเฟรชหน้าเว็บ");
+            }
+
             if (this.onPacketCallback)
                 this.onPacketCallback(packet);
         }
 
         this.webSocket.onclose = (event) => {
+            if (!this.closedByClient && event.code !== 1000 && this.onReconnectCallback && this.reconnectAttempt < RECONNECT_DELAYS_MS.length) {
+                const delay = RECONNECT_DELAYS_MS[this.reconnectAttempt++];
+                console.log(`🔌 Connection lost (code ${event.code}), reconnecting in ${delay}ms...`);
+                setTimeout(() => {
+                    this.reconnecting = true;
+                    this.open();
+                }, delay);
+                return;
+            }
             if (this.onDisconnectCallback) {
                 this.onDisconnectCallback(event.code, event.reason);
             }
@@ -164,7 +365,12 @@ export class NetService {
         this.onDisconnectCallback = callback;
     }
 
+    public onReconnect(callback: () => void) {
+        this.onReconnectCallback = callback;
+    }
+
     public disconnect() {
+        this.closedByClient = true;
         if (this.webSocket && this.webSocket.readyState === WebSocket.OPEN) {
             this.webSocket.close();
             console.log("WebSocket connection closed.");
@@ -177,7 +383,7 @@ export class NetService {
         this.onPacketCallback = callback;
     }
 
-    sendPacket(packet: Packet) {
+    private encodePacket(packet: Packet): Uint8Array {
         const packetId = packet.id;
         const packetData = JSON.stringify(packet, (key, value) =>
             key == "id" ? undefined : value
@@ -191,6 +397,11 @@ export class NetService {
         );
         mergedArray.set(packetIdArray);
         mergedArray.set(packetDataArray, packetIdArray.length);
+        return mergedArray;
+    }
+
+    sendPacket(packet: Packet) {
+        const mergedArray = this.encodePacket(packet);
 
         if (this.webSocket && this.webSocket.readyState === WebSocket.OPEN) {
             console.log(`🚀 [Frontend] Sending Packet ID: ${packet.id}`);
@@ -1,5 +1,5 @@
 import { writable, Writable, get } from "svelte/store";
-import { NetService, type Packet, PacketTypes, type ConnectPacket, ChangeGameStatePacket, GameState, type QuestionShowPacket, type QuestionAnswerPacket, type PlayerRevealPacket, PlayerJoinPacket, LeaderboardEntry, LeaderboardPacket, type PlayerRankPacket, type PlayerAnswerFeedbackPacket } from "../net";
+import { NetService, type Packet, PacketTypes, type ConnectPacket, ChangeGameStatePacket, GameState, type QuestionShowPacket, type QuestionAnswerPacket, type PlayerAnswer, type PlayerRevealPacket, PlayerJoinPacket, LeaderboardEntry, LeaderboardPacket, type PlayerRankPacket, type PlayerAnswerFeedbackPacket, type ResumeTokenPacket, type TickPacket, type NextQuestionPacket, type ErrorPacket, type SkipQuestionPacket, ErrorCode } from "../net";
 import type { QuizQuestion } from "../../model/quiz";
 import type { Player } from '../../model/quiz';
 
@@ -10,6 +10,8 @@ export const leaderboard: Writable<LeaderboardEntry[]> = writable([]);
 export const rank: Writable<number> = writable(0);
 export const maxStreak: Writable<number> = writable(0);
 export const streakBonus: Writable<number> = writable(0);
+export const selfPaced: Writable<boolean> = writable(false);
+export const timeLeft: Writable<number> = writable(0);
 export const currentPlayer: Writable<Player> = writable({
     id: "",
     name: "Loading..."
@@ -23,19 +25,31 @@ export function resetPlayerStores() {
     rank.set(0);
     maxStreak.set(0);
     streakBonus.set(0);
+    selfPaced.set(false);
+    timeLeft.set(0);
     currentPlayer.set({ id: "", name: "Loading..." });
 }
 
+const RESUME_TOKEN_KEY = "resumeToken";
+
+export function storedResumeToken(code: string): string | undefined {
+    return sessionStorage.getItem(`${RESUME_TOKEN_KEY}:${code}`) ?? undefined;
+}
+
 export class PlayerGame {
     public navigate?: (path: string) => void;
     private messageHandlers: ((packet: Packet) => void)[] = [];
     private net: NetService;
+    private pendingCode: string | null = null;
+    private pendingName: string = "";
+    private resuming: boolean = false;
 
     constructor(navigateFunction?: (path: string) => void) {
         console.trace("Created from here:");
         this.net = new NetService();
         this.net.onPacket(p => this.onPacket(p));
         this.net.onDisconnect((code, reason) => this.handleDisconnect(code, reason));
+        this.net.onReconnect(() => this.resume());
         if (navigateFunction) {
             this.navigate = navigateFunction;
         }
@@ -55,15 +69,41 @@ export class PlayerGame {
             id: PacketTypes.Connect,
             code: code,
             name: name,
+            resumeToken: storedResumeToken(code),
         }
-            this.net.sendPacket(packet);
+        this.pendingCode = code;
+        this.pendingName = name;
+        this.net.sendPacket(packet);
     }
 
-    answer(questionIndex: number, choiceIndex: number) {
+    private resume() {
+        if (!this.pendingCode) {
+            return;
+        }
+        console.log("🔁 Reconnected, resuming player session...");
+        this.resuming = true;
+        let packet: ConnectPacket = {
+            id: PacketTypes.Connect,
+            code: this.pendingCode,
+            name: this.pendingName,
+            resumeToken: storedResumeToken(this.pendingCode),
+        }
+        this.net.sendPacket(packet);
+    }
+
+    answer(questionIndex: number, answer: PlayerAnswer) {
         let packet: QuestionAnswerPacket = {
+            ...answer,
             id: PacketTypes.Answer,
             question: questionIndex,
-            choice: choiceIndex,
+            choice: answer.choice ?? 0,
+        };
+        this.net.sendPacket(packet);
+    }
+
+    nextQuestion() {
+        let packet: NextQuestionPacket = {
+            id: PacketTypes.NextQuestion,
         };
         this.net.sendPacket(packet);
     }
@@ -98,11 +138,37 @@ export class PlayerGame {
                 let data = packet as PlayerJoinPacket;
                 console.log("DEBUG: Player Data:", data.player);
                 currentPlayer.set(data.player);
+                this.resuming = false;
                 if (this.navigate) {
                     this.navigate('/play');
                 }
                 break;
             }
+            case PacketTypes.Error: {
+                let data = packet as ErrorPacket;
+                console.error(`❌ [Player] ${data.code}: ${data.message}`);
+                const joinErrors: Partial<Record<ErrorCode, string>> = {
+                    [ErrorCode.GameNotFound]: "ไม่พบห้องเกมนี้",
+                    [ErrorCode.NameTaken]: "ชื่อนี้ถูกใช้แล้ว",
+                    [ErrorCode.GameStarted]: "เกมเริ่มไปแล้ว",
+                };
+                const joinError = joinErrors[data.code];
+                if (joinError && (get(currentPlayer).id === "" || this.resuming)) {
+                    this.resuming = false;
+                    alert(joinError);
+                    if (this.navigate) {
+                        this.navigate('/');
+                    }
+                }
+                break;
+            }
+            case PacketTypes.ResumeToken: {
+                let data = packet as ResumeTokenPacket;
+                if (this.pendingCode) {
+                    sessionStorage.setItem(`${RESUME_TOKEN_KEY}:${this.pendingCode}`, data.token);
+                }
+                break;
+            }
             case PacketTypes.ChangeGameState: {
                 let data = packet as ChangeGameStatePacket;
                 if (data.state === GameState.GameEndedState) {
@@ -117,6 +183,20 @@ export class PlayerGame {
             case PacketTypes.QuestionShow: {
                 let data = packet as QuestionShowPacket;
                 currentQuestion.set({ ...data.question, index: data.questionIndex });
+                selfPaced.set(data.selfPaced ?? false);
+                break;
+            }
+            case PacketTypes.SkipQuestion: {
+                let data = packet as SkipQuestionPacket;
+                console.log(`⏭️ Question ${data.questionIndex} skipped by host`);
+                if (get(currentQuestion)?.index === data.questionIndex) {
+                    currentQuestion.set(null);
+                }
+                break;
+            }
+            case PacketTypes.Tick: {
+                let data = packet as TickPacket;
+                timeLeft.set(data.tick);
                 break;
             }
             case PacketTypes.PlayerAnswerFeedback: {